@@ -0,0 +1,216 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// GlobWarning describes a valid but redundant or suspicious construct of a
+// glob pattern.
+type GlobWarning struct {
+
+	// Pattern is the glob pattern.
+	Pattern string
+
+	// Index is the index where the construct starts.
+	Index int
+
+	// Message describes the issue.
+	Message string
+}
+
+func (w GlobWarning) String() string {
+	return fmt.Sprintf("glob warning: in %q at index %d: %s", w.Pattern, w.Index, w.Message)
+}
+
+// Analyze returns warnings about the constructs of pattern that are
+// redundant or likely mistakes, in the order they appear, or the syntax
+// error of pattern if it is invalid, such as the one of "[z-a]", which
+// matches nothing. It is meant to validate patterns written by users, for
+// instance in configuration files.
+//
+// The following constructs are reported:
+//
+//  - "**/**" and "*/**", which match the same paths as "**" (save the
+//    parent directory itself, for the latter).
+//  - "**" not forming a whole path component, as in "a**" or "**.go", which
+//    matches across slashes unlike "*", and more than two consecutive stars.
+//  - Escapes of characters that are not special.
+//  - Bracket expressions matching a single ordinary character, like "[a]".
+//  - Brace groups with a single alternative, like "{a}", or with duplicate
+//    alternatives, like "{a,b,a}".
+func Analyze(pattern string) ([]GlobWarning, error) {
+	if _, err := CompileGlob(pattern); err != nil {
+		return nil, err
+	}
+	a := analyzer{pattern: pattern}
+	a.run()
+	sort.SliceStable(a.warnings, func(i, j int) bool {
+		return a.warnings[i].Index < a.warnings[j].Index
+	})
+	return a.warnings, nil
+}
+
+type analyzer struct {
+	pattern  string
+	warnings []GlobWarning
+}
+
+func (a *analyzer) warn(index int, format string, args ...interface{}) {
+	a.warnings = append(a.warnings, GlobWarning{Pattern: a.pattern, Index: index, Message: fmt.Sprintf(format, args...)})
+}
+
+// analyzeGroup is a brace group being analyzed.
+type analyzeGroup struct {
+	index    int
+	altStart int
+	alts     []string
+}
+
+func (a *analyzer) run() {
+	s := a.pattern
+	i := 0
+	if strings.HasPrefix(s, "!") {
+		i++
+	}
+
+	// Components are tracked to detect redundant sequences of stars.
+	var prev string
+	compStart := i
+	endComponent := func(end int) {
+		comp := s[compStart:end]
+		switch {
+		case comp == "**" && prev == "**":
+			a.warn(compStart-len("**/"), `"**/**" is equivalent to "**"`)
+		case comp == "**" && prev == "*":
+			a.warn(compStart-len("*/"), `"*/**" is equivalent to "**", except for the parent directory`)
+		}
+		prev = comp
+		compStart = end + 1
+	}
+
+	var groups []analyzeGroup
+	for i < len(s) {
+		switch c := s[i]; c {
+		case '\\':
+			if r, _ := utf8.DecodeRuneInString(s[i+1:]); !strings.ContainsRune(`\*?[]{},!%`, r) {
+				a.warn(i, "unnecessary escape of %q", r)
+			}
+			_, n := utf8.DecodeRuneInString(s[i+1:])
+			i += 1 + n
+			continue
+		case '[':
+			i = a.class(i)
+			continue
+		case '*':
+			n := 1
+			for i+n < len(s) && s[i+n] == '*' {
+				n++
+			}
+			before := i == 0 || strings.IndexByte("/{,", s[i-1]) != -1
+			after := i+n == len(s) || strings.IndexByte("/},", s[i+n]) != -1
+			switch {
+			case n > 2:
+				a.warn(i, "%d consecutive stars are equivalent to \"**\"", n)
+			case n == 2 && !(before && after):
+				a.warn(i, `"**" not forming a whole path component matches across "/"`)
+			}
+			i += n
+			continue
+		case '{':
+			if _, _, n, ok := braceRange(s[i+1:]); ok {
+				i += 1 + n
+				continue
+			}
+			groups = append(groups, analyzeGroup{index: i, altStart: i + 1})
+		case ',':
+			if len(groups) > 0 {
+				g := &groups[len(groups)-1]
+				g.alts = append(g.alts, s[g.altStart:i])
+				g.altStart = i + 1
+			}
+		case '}':
+			g := groups[len(groups)-1]
+			groups = groups[:len(groups)-1]
+			g.alts = append(g.alts, s[g.altStart:i])
+			a.group(g)
+		case '/':
+			endComponent(i)
+		}
+		i++
+	}
+	endComponent(len(s))
+}
+
+// class analyzes the bracket expression starting at index open, and returns
+// the index following it.
+func (a *analyzer) class(open int) int {
+	s := a.pattern
+	i := open + 1
+	negated := i < len(s) && (s[i] == '!' || s[i] == '^')
+	if negated {
+		i++
+	}
+	var runes []rune
+	for first := true; i < len(s); first = false {
+		if s[i] == ']' && !first {
+			break
+		}
+		if strings.HasPrefix(s[i:], "[:") {
+			if end := strings.Index(s[i+len("[:"):], ":]"); end != -1 {
+				// Named classes match several characters.
+				runes = append(runes, -1, -1)
+				i += len("[:") + end + len(":]")
+				continue
+			}
+		}
+		if r, n, ok := collatingElement(s[i:], '.'); ok {
+			runes = append(runes, r)
+			i += n
+			continue
+		}
+		if r, n, ok := collatingElement(s[i:], '='); ok {
+			runes = append(runes, r)
+			i += n
+			continue
+		}
+		if s[i] == '\\' {
+			r, n := utf8.DecodeRuneInString(s[i+1:])
+			if !strings.ContainsRune(`\]-^!`, r) {
+				a.warn(i, "unnecessary escape of %q", r)
+			}
+			runes = append(runes, r)
+			i += 1 + n
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(s[i:])
+		runes = append(runes, r)
+		i += n
+	}
+	if len(runes) == 1 && !negated && !strings.ContainsRune(`\*?[]{},!`, runes[0]) {
+		a.warn(open, "bracket expression %q matches a single character", s[open:i+1])
+	}
+	return i + 1
+}
+
+// group analyzes the alternatives of a brace group.
+func (a *analyzer) group(g analyzeGroup) {
+	if len(g.alts) == 1 {
+		a.warn(g.index, "brace group with a single alternative %q", g.alts[0])
+		return
+	}
+	seen := make(map[string]bool)
+	for _, alt := range g.alts {
+		if seen[alt] {
+			a.warn(g.index, "duplicate alternative %q in brace group", alt)
+		}
+		seen[alt] = true
+	}
+}
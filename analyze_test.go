@@ -0,0 +1,64 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	tcases := []struct {
+		Pattern  string
+		Expected []GlobWarning
+	}{
+		{"src/**/*.go", nil},
+		{"!build/**", nil},
+		{`\*.go`, nil},
+		{"[*]", nil},
+		{"[!a]", nil},
+		{"{a,b}/{1..3}", nil},
+		{"{**,x}/y", nil},
+		{"src/**/**/*.go", []GlobWarning{{Index: 4, Message: `"**/**" is equivalent to "**"`}}},
+		{"src/*/**", []GlobWarning{{Index: 4, Message: `"*/**" is equivalent to "**", except for the parent directory`}}},
+		{"**.go", []GlobWarning{{Index: 0, Message: `"**" not forming a whole path component matches across "/"`}}},
+		{"src/***", []GlobWarning{{Index: 4, Message: `3 consecutive stars are equivalent to "**"`}}},
+		{`\a/[\b]`, []GlobWarning{
+			{Index: 0, Message: `unnecessary escape of 'a'`},
+			{Index: 3, Message: `bracket expression "[\\b]" matches a single character`},
+			{Index: 4, Message: `unnecessary escape of 'b'`},
+		}},
+		{"x/{a}", []GlobWarning{{Index: 2, Message: `brace group with a single alternative "a"`}}},
+		{"{a,{b,b}}", []GlobWarning{{Index: 3, Message: `duplicate alternative "b" in brace group`}}},
+		{"[[.a.]}]", nil},
+		{"[[=a=]}]x", nil},
+		{"[[.a.]]/x", []GlobWarning{{Index: 0, Message: `bracket expression "[[.a.]]" matches a single character`}}},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			warnings, err := Analyze(tc.Pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range tc.Expected {
+				tc.Expected[i].Pattern = tc.Pattern
+			}
+			if !reflect.DeepEqual(warnings, tc.Expected) {
+				t.Fatalf("expected %v, got %v", tc.Expected, warnings)
+			}
+		})
+	}
+
+	if _, err := Analyze("[z-a]"); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("expected %v, got %v", ErrInvalidRange, err)
+	}
+	w := GlobWarning{Pattern: "x/{a}", Index: 2, Message: "message"}
+	if s := w.String(); s != `glob warning: in "x/{a}" at index 2: message` {
+		t.Errorf("unexpected string %q", s)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"io"
+	"iter"
+	"path"
+)
+
+// MatchTar returns an iterator over the headers of the entries of r whose
+// name matches m, which is typically a *Glob or a *GlobSet. The contents of
+// each yielded entry can be read from r until the iteration resumes. If
+// reading r fails, the error is yielded with a nil header, and the iteration
+// stops.
+//
+// Entry names are matched the way archive entries are usually extracted: a
+// leading "./" or "/" is ignored, and so are "." and ".." components. As
+// with Glob.MatchInfo, a directory also matches if its name followed by "/"
+// matches, whether the entry name ends with a slash or not.
+func MatchTar(r *tar.Reader, m Matcher) iter.Seq2[*tar.Header, error] {
+	return func(yield func(*tar.Header, error) bool) {
+		for {
+			hdr, err := r.Next()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if matchArchiveEntry(m, hdr.Name, hdr.Typeflag == tar.TypeDir) && !yield(hdr, nil) {
+				return
+			}
+		}
+	}
+}
+
+// MatchZip returns an iterator over the files of r whose name matches m. See
+// MatchTar for details on how names are matched.
+func MatchZip(r *zip.Reader, m Matcher) iter.Seq[*zip.File] {
+	return func(yield func(*zip.File) bool) {
+		for _, f := range r.File {
+			if matchArchiveEntry(m, f.Name, f.FileInfo().IsDir()) && !yield(f) {
+				return
+			}
+		}
+	}
+}
+
+func matchArchiveEntry(m Matcher, name string, isDir bool) bool {
+	name = path.Clean("/" + name)[1:]
+	if name == "" {
+		// The root of the archive itself.
+		return false
+	}
+	return m.Match(name) || isDir && m.Match(name+"/")
+}
@@ -0,0 +1,110 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestMatchTar(t *testing.T) {
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	for _, hdr := range []*tar.Header{
+		{Name: "./", Typeflag: tar.TypeDir},
+		{Name: "./etc/", Typeflag: tar.TypeDir},
+		{Name: "./etc/passwd", Typeflag: tar.TypeReg, Size: 4},
+		{Name: "usr/lib", Typeflag: tar.TypeDir},
+		{Name: "usr/lib/libc.so", Typeflag: tar.TypeReg, Size: 4},
+		{Name: "/usr/lib/libm.so", Typeflag: tar.TypeSymlink, Linkname: "libc.so"},
+	} {
+		if err := w.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Size > 0 {
+			if _, err := w.Write([]byte(hdr.Name[len(hdr.Name)-4:])); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tcases := []struct {
+		Patterns []string
+		Expected []string
+	}{
+		{[]string{"etc/*"}, []string{"./etc/", "./etc/passwd"}},
+		{[]string{"**/"}, []string{"./etc/", "usr/lib"}},
+		{[]string{"**/*.so"}, []string{"usr/lib/libc.so", "/usr/lib/libm.so"}},
+		{[]string{"etc/passwd", "usr/lib/libc.so"}, []string{"./etc/passwd", "usr/lib/libc.so"}},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.Patterns[0], func(t *testing.T) {
+			r := tar.NewReader(bytes.NewReader(buf.Bytes()))
+			var names []string
+			for hdr, err := range MatchTar(r, MustCompileGlobSet(tc.Patterns)) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				names = append(names, hdr.Name)
+				if hdr.Size > 0 {
+					data, err := io.ReadAll(r)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if string(data) != hdr.Name[len(hdr.Name)-4:] {
+						t.Fatalf("unexpected contents %q for %q", data, hdr.Name)
+					}
+				}
+			}
+			if !reflect.DeepEqual(names, tc.Expected) {
+				t.Fatalf("expected %q, got %q", tc.Expected, names)
+			}
+		})
+	}
+
+	t.Run("Truncated", func(t *testing.T) {
+		r := tar.NewReader(bytes.NewReader(buf.Bytes()[:700]))
+		var err error
+		for _, err = range MatchTar(r, MustCompileGlob("**")) {
+		}
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("expected %v, got %v", io.ErrUnexpectedEOF, err)
+		}
+	})
+}
+
+func TestMatchZip(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range []string{"docs/", "docs/index.md", "main.go", "cmd/tool/main.go"} {
+		if _, err := w.Create(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for f := range MatchZip(r, MustCompileGlob("{docs,**/*.go}")) {
+		names = append(names, f.Name)
+	}
+	if expected := []string{"docs/", "main.go", "cmd/tool/main.go"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %q, got %q", expected, names)
+	}
+}
@@ -0,0 +1,436 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	ErrMalformedEncoding = errors.New("malformed binary encoding")
+)
+
+// Globs are encoded as their parsed form, preceded by a header made of a
+// magic string and a version number. Decoding a glob rebuilds its matcher
+// from the parsed form, without parsing the pattern again.
+//
+// Version 2 adds the subtracted sets to the encoding of sets. Globs are
+// encoded the same way in both versions, and version 1 is still decoded.
+const (
+	globMagic    = "shg"
+	globSetMagic = "shs"
+	binVersion   = 2
+
+	// maxNodeDepth bounds the nesting of decoded brace groups.
+	maxNodeDepth = 1024
+)
+
+// Bits encoding GlobOptions.
+const (
+	optCaseInsensitive = 1 << iota
+	optUnanchored
+	optCleanPath
+	optPeriod
+	optCrossSeparators
+	optTrailingSlash
+	optMatchBase
+	optAnchorAnywhere
+
+	// optSeparator is set if the encoded options are followed by a custom
+	// separator.
+	optSeparator
+
+	optSkipHidden
+	optUTF8Bytes
+	optUTF8Strict
+)
+
+// MarshalBinary encodes the compiled glob into a compact binary form, which
+// UnmarshalBinary decodes without parsing the pattern again. This allows
+// compiling large sets of patterns once, and loading them quickly.
+func (g *Glob) MarshalBinary() ([]byte, error) {
+	var w binWriter
+	w.header(globMagic)
+	w.glob(g)
+	return w.buf, nil
+}
+
+// UnmarshalBinary decodes a glob encoded by MarshalBinary into g.
+func (g *Glob) UnmarshalBinary(data []byte) error {
+	r := binReader{data: data}
+	r.header(globMagic)
+	glob := r.glob()
+	if err := r.end(); err != nil {
+		return err
+	}
+	*g = *glob
+	return nil
+}
+
+// MarshalBinary encodes the globs of the set into a compact binary form, as
+// Glob.MarshalBinary does.
+func (s *GlobSet) MarshalBinary() ([]byte, error) {
+	var w binWriter
+	w.header(globSetMagic)
+	w.globSet(s)
+	return w.buf, nil
+}
+
+// UnmarshalBinary decodes a set encoded by MarshalBinary into s.
+func (s *GlobSet) UnmarshalBinary(data []byte) error {
+	r := binReader{data: data}
+	r.header(globSetMagic)
+	set := r.globSet()
+	if err := r.end(); err != nil {
+		return err
+	}
+	*s = *set
+	return nil
+}
+
+type binWriter struct {
+	buf []byte
+}
+
+func (w *binWriter) header(magic string) {
+	w.buf = append(w.buf, magic...)
+	w.buf = append(w.buf, binVersion)
+}
+
+func (w *binWriter) uvarint(v uint64) {
+	w.buf = binary.AppendUvarint(w.buf, v)
+}
+
+func (w *binWriter) varint(v int64) {
+	w.buf = binary.AppendVarint(w.buf, v)
+}
+
+func (w *binWriter) bool(v bool) {
+	if v {
+		w.buf = append(w.buf, 1)
+	} else {
+		w.buf = append(w.buf, 0)
+	}
+}
+
+func (w *binWriter) string(s string) {
+	w.uvarint(uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+func (w *binWriter) glob(g *Glob) {
+	w.string(g.pattern)
+	var opts uint64
+	for _, o := range []struct {
+		bit uint64
+		set bool
+	}{
+		{optCaseInsensitive, g.opts.CaseInsensitive},
+		{optUnanchored, g.opts.Unanchored},
+		{optCleanPath, g.opts.CleanPath},
+		{optPeriod, g.opts.Period},
+		{optCrossSeparators, g.opts.CrossSeparators},
+		{optTrailingSlash, g.opts.TrailingSlash},
+		{optMatchBase, g.opts.MatchBase},
+		{optAnchorAnywhere, g.opts.AnchorAnywhere},
+		{optSeparator, g.opts.Separator != 0},
+		{optSkipHidden, g.opts.SkipHidden},
+		{optUTF8Bytes, g.opts.InvalidUTF8 == UTF8Bytes},
+		{optUTF8Strict, g.opts.InvalidUTF8 == UTF8Strict},
+	} {
+		if o.set {
+			opts |= o.bit
+		}
+	}
+	w.uvarint(opts)
+	if g.opts.Separator != 0 {
+		w.varint(int64(g.opts.Separator))
+	}
+	w.bool(g.negated)
+	w.string(g.prefix)
+	w.uvarint(uint64(g.literals))
+	w.uvarint(uint64(g.wildcards))
+	w.nodes(g.nodes)
+}
+
+func (w *binWriter) globSet(s *GlobSet) {
+	w.uvarint(uint64(len(s.globs)))
+	for _, g := range s.globs {
+		w.glob(g)
+	}
+	w.uvarint(uint64(len(s.subtracted)))
+	for _, t := range s.subtracted {
+		w.globSet(t)
+	}
+}
+
+func (w *binWriter) nodes(nodes []node) {
+	w.uvarint(uint64(len(nodes)))
+	for _, n := range nodes {
+		op := byte(n.op)
+		if n.capture {
+			op |= captureBit
+		}
+		w.buf = append(w.buf, op)
+		switch n.op {
+		case nodeRune:
+			w.varint(int64(n.r))
+		case nodeClass, nodeStar:
+			w.class(n.class)
+		case nodeAlt:
+			w.uvarint(uint64(len(n.alts)))
+			for _, alt := range n.alts {
+				w.nodes(alt)
+			}
+		}
+	}
+}
+
+// captureBit is set in the encoded operation of capturing nodes.
+const captureBit = 0x80
+
+// Predefined classes are encoded by reference.
+const (
+	classRanges = iota
+	classAnyRune
+	classAnyButSlash
+)
+
+func (w *binWriter) class(c *charClass) {
+	switch c {
+	case anyRune:
+		w.buf = append(w.buf, classAnyRune)
+		return
+	case anyButSlash:
+		w.buf = append(w.buf, classAnyButSlash)
+		return
+	}
+	w.buf = append(w.buf, classRanges)
+	w.bool(c.negated)
+	w.uvarint(uint64(len(c.ranges)))
+	for _, rg := range c.ranges {
+		w.varint(int64(rg.lo))
+		w.varint(int64(rg.hi))
+	}
+}
+
+// binReader decodes what binWriter encodes. Once an error occurs, it is
+// recorded, and all further reads return zero values.
+type binReader struct {
+	data    []byte
+	err     error
+	depth   int
+	version byte
+}
+
+func (r *binReader) fail() {
+	if r.err == nil {
+		r.err = ErrMalformedEncoding
+	}
+	r.data = nil
+}
+
+func (r *binReader) end() error {
+	if r.err == nil && len(r.data) != 0 {
+		r.fail()
+	}
+	return r.err
+}
+
+func (r *binReader) header(magic string) {
+	if len(r.data) < len(magic)+1 || string(r.data[:len(magic)]) != magic {
+		r.fail()
+		return
+	}
+	if r.version = r.data[len(magic)]; r.version < 1 || r.version > binVersion {
+		r.fail()
+		return
+	}
+	r.data = r.data[len(magic)+1:]
+}
+
+func (r *binReader) byte() byte {
+	if len(r.data) == 0 {
+		r.fail()
+		return 0
+	}
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b
+}
+
+func (r *binReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *binReader) varint() int64 {
+	v, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+// count reads a number of elements, each taking at least a byte.
+func (r *binReader) count() int {
+	n := r.uvarint()
+	if n > uint64(len(r.data)) {
+		r.fail()
+		return 0
+	}
+	return int(n)
+}
+
+func (r *binReader) bool() bool {
+	switch r.byte() {
+	case 0:
+		return false
+	case 1:
+		return true
+	}
+	r.fail()
+	return false
+}
+
+func (r *binReader) string() string {
+	n := r.count()
+	s := string(r.data[:n])
+	r.data = r.data[n:]
+	return s
+}
+
+// rune reads a rune, which may be that of an invalid byte decoded as a raw
+// byte, see UTF8Bytes.
+func (r *binReader) rune() rune {
+	v := r.varint()
+	if v < 0 || v >= rawBytesEnd {
+		r.fail()
+		return 0
+	}
+	return rune(v)
+}
+
+func (r *binReader) glob() *Glob {
+	g := &Glob{pattern: r.string(), metrics: currentMetrics()}
+	opts := r.uvarint()
+	g.opts = GlobOptions{
+		CaseInsensitive: opts&optCaseInsensitive != 0,
+		Unanchored:      opts&optUnanchored != 0,
+		CleanPath:       opts&optCleanPath != 0,
+		Period:          opts&optPeriod != 0,
+		CrossSeparators: opts&optCrossSeparators != 0,
+		TrailingSlash:   opts&optTrailingSlash != 0,
+		MatchBase:       opts&optMatchBase != 0,
+		AnchorAnywhere:  opts&optAnchorAnywhere != 0,
+		SkipHidden:      opts&optSkipHidden != 0,
+	}
+	switch opts & (optUTF8Bytes | optUTF8Strict) {
+	case optUTF8Bytes:
+		g.opts.InvalidUTF8 = UTF8Bytes
+	case optUTF8Strict:
+		g.opts.InvalidUTF8 = UTF8Strict
+	case optUTF8Bytes | optUTF8Strict:
+		r.fail()
+	}
+	if opts&optSeparator != 0 {
+		if g.opts.Separator = r.rune(); !validSeparator(g.opts.Separator) {
+			r.fail()
+		}
+	}
+	g.negated = r.bool()
+	g.prefix = r.string()
+	g.literals = int(r.uvarint())
+	g.wildcards = int(r.uvarint())
+	g.nodes = r.nodes()
+	if r.err != nil {
+		return nil
+	}
+	g.build()
+	return g
+}
+
+func (r *binReader) globSet() *GlobSet {
+	if r.depth++; r.depth > maxNodeDepth {
+		r.fail()
+	}
+	defer func() { r.depth-- }()
+
+	n := r.count()
+	globs := make([]*Glob, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		globs = append(globs, r.glob())
+	}
+	var subtracted []*GlobSet
+	if r.version >= 2 {
+		n := r.count()
+		for i := 0; i < n && r.err == nil; i++ {
+			subtracted = append(subtracted, r.globSet())
+		}
+	}
+	if r.err != nil {
+		return nil
+	}
+	set := NewGlobSet(globs)
+	set.subtracted = subtracted
+	return set
+}
+
+func (r *binReader) nodes() []node {
+	if r.depth++; r.depth > maxNodeDepth {
+		r.fail()
+	}
+	defer func() { r.depth-- }()
+
+	n := r.count()
+	nodes := make([]node, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		op := r.byte()
+		nd := node{op: nodeOp(op &^ captureBit), capture: op&captureBit != 0}
+		switch nd.op {
+		case nodeRune:
+			nd.r = r.rune()
+		case nodeClass, nodeStar:
+			nd.class = r.class()
+		case nodeAlt:
+			alts := r.count()
+			for j := 0; j < alts && r.err == nil; j++ {
+				nd.alts = append(nd.alts, r.nodes())
+			}
+		default:
+			r.fail()
+		}
+		nodes = append(nodes, nd)
+	}
+	return nodes
+}
+
+func (r *binReader) class() *charClass {
+	switch r.byte() {
+	case classAnyRune:
+		return anyRune
+	case classAnyButSlash:
+		return anyButSlash
+	case classRanges:
+	default:
+		r.fail()
+		return nil
+	}
+	c := &charClass{negated: r.bool()}
+	n := r.count()
+	for i := 0; i < n && r.err == nil; i++ {
+		c.ranges = append(c.ranges, runeRange{r.rune(), r.rune()})
+	}
+	return c
+}
@@ -0,0 +1,137 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGlobBinary(t *testing.T) {
+	tcases := []struct {
+		Pattern string
+		Options GlobOptions
+	}{
+		{Pattern: "*.go"},
+		{Pattern: "src/**/[!a-z]?.{c,h,cc}"},
+		{Pattern: "!build/**"},
+		{Pattern: "[[:digit:]]*", Options: GlobOptions{CaseInsensitive: true}},
+		{Pattern: "*.TXT", Options: GlobOptions{CaseInsensitive: true, Period: true}},
+		{Pattern: "b/c", Options: GlobOptions{Unanchored: true, CleanPath: true}},
+		{Pattern: "a*b", Options: GlobOptions{CrossSeparators: true, TrailingSlash: true}},
+		{Pattern: "héllo/ø*"},
+		{Pattern: "a.*.b/c", Options: GlobOptions{Separator: '.'}},
+		{Pattern: "**/*.txt", Options: GlobOptions{SkipHidden: true}},
+		{Pattern: "caf\xe9/*", Options: GlobOptions{InvalidUTF8: UTF8Bytes}},
+		{Pattern: "*.txt", Options: GlobOptions{InvalidUTF8: UTF8Strict}},
+	}
+	inputs := []string{
+		"", "main.go", ".go", "src/A.c", "src/x/y/0.h", "src/x/y/ab.h", "build/x",
+		"1abc", "README.TXT", "notes.txt", ".hidden.txt", "a/b/c/d", "./a//b/c",
+		"a/x/b", "a/x/b/", "héllo/øre", "héllo/ore", "a.x.b/c", "a.x/y.b/c",
+		"caf\xe9/x", "caf\xe8/x", "\xff.txt",
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, tc.Options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := g.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var decoded Glob
+			if err := decoded.UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			if decoded.String() != g.String() {
+				t.Errorf("decoded pattern is %q, expected %q", decoded.String(), g.String())
+			}
+			if decoded.Prefix() != g.Prefix() {
+				t.Errorf("decoded prefix is %q, expected %q", decoded.Prefix(), g.Prefix())
+			}
+			for _, in := range inputs {
+				if actual, expected := decoded.Match(in), g.Match(in); actual != expected {
+					t.Errorf("decoded glob matched %q: %v, expected %v", in, actual, expected)
+				}
+				actual, _ := decoded.Capture(in)
+				if expected, _ := g.Capture(in); !reflect.DeepEqual(actual, expected) {
+					t.Errorf("decoded glob captured %q in %q, expected %q", actual, in, expected)
+				}
+			}
+
+			for i := range data {
+				if err := new(Glob).UnmarshalBinary(data[:i]); !errors.Is(err, ErrMalformedEncoding) {
+					t.Fatalf("decoding %d bytes of %d: expected ErrMalformedEncoding, got %v", i, len(data), err)
+				}
+			}
+			if err := new(Glob).UnmarshalBinary(append(data, 0)); !errors.Is(err, ErrMalformedEncoding) {
+				t.Errorf("decoding trailing data: expected ErrMalformedEncoding, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGlobSetBinary(t *testing.T) {
+	set := MustCompileGlobSet([]string{"*.go", "docs/**", "Makefile", "[!a-z]*.md"})
+	data, err := set.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded GlobSet
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Globs()) != len(set.Globs()) {
+		t.Fatalf("decoded %d globs, expected %d", len(decoded.Globs()), len(set.Globs()))
+	}
+	for _, in := range []string{"main.go", "cmd/main.go", "docs/", "docs/a/b", "Makefile", "README.md", "notes.md"} {
+		if actual, expected := decoded.MatchIndices(in), set.MatchIndices(in); len(actual) != len(expected) {
+			t.Errorf("decoded set matched %q with %v, expected %v", in, actual, expected)
+		}
+	}
+
+	subtracted := set.Subtract(MustCompileGlobSet([]string{"docs/internal/**"}))
+	if data, err = subtracted.MarshalBinary(); err != nil {
+		t.Fatal(err)
+	}
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	for _, in := range []string{"docs/a", "docs/internal/a", "main.go"} {
+		if actual, expected := decoded.Match(in), subtracted.Match(in); actual != expected {
+			t.Errorf("decoded set matched %q: %v, expected %v", in, actual, expected)
+		}
+	}
+	for i := range data {
+		if err := new(GlobSet).UnmarshalBinary(data[:i]); !errors.Is(err, ErrMalformedEncoding) {
+			t.Fatalf("decoding %d bytes of %d: expected ErrMalformedEncoding, got %v", i, len(data), err)
+		}
+	}
+
+	// Version 1 encodings have no subtracted sets.
+	if data, err = set.MarshalBinary(); err != nil {
+		t.Fatal(err)
+	}
+	v1 := append([]byte(globSetMagic+"\x01"), data[len(globSetMagic)+1:len(data)-1]...)
+	if err := decoded.UnmarshalBinary(v1); err != nil {
+		t.Fatalf("decoding version 1: %v", err)
+	}
+	if !decoded.Match("docs/internal/a") {
+		t.Error("decoded version 1 set did not match")
+	}
+
+	glob, err := MustCompileGlob("*.go").MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := decoded.UnmarshalBinary(glob); !errors.Is(err, ErrMalformedEncoding) {
+		t.Errorf("decoding a glob as a set: expected ErrMalformedEncoding, got %v", err)
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"strings"
+)
+
+// ExpandBraces returns the list of patterns produced by expanding the curly
+// brace groups of pattern, in order. For instance, "{a,b}/x" expands to
+// "a/x" and "b/x", "x.{c,{h,hpp}}" to "x.c", "x.h" and "x.hpp", and
+// "shard-{a..c}" to "shard-a", "shard-b" and "shard-c".
+//
+// Matching a string against any of the expanded patterns is equivalent to
+// matching it against the original pattern. Escaped braces, braces inside
+// bracket expressions and unbalanced braces are kept as they are, and so is
+// the leading "!" of negated patterns, which is repeated on every expansion.
+// Expansions are written such that they keep the meaning of the original
+// wildcards: "*{,x}*" expands to "*x*" and "*", rather than "**", and
+// "{,a/}!x" to `\!x` and "a/!x", which are not negated.
+func ExpandBraces(pattern string) []string {
+	neg := ""
+	if strings.HasPrefix(pattern, "!") {
+		neg, pattern = "!", pattern[1:]
+	}
+	expanded := expandBraces(pattern)
+	for i, e := range expanded {
+		if strings.HasPrefix(e, "!") {
+			e = `\` + e
+		}
+		expanded[i] = neg + e
+	}
+	return expanded
+}
+
+// escapeGlobRune returns r as a pattern matching r literally.
+func escapeGlobRune(r rune) string {
+	if strings.ContainsRune(`\*?[]{},!`, r) {
+		return `\` + string(r)
+	}
+	return string(r)
+}
+
+// Expand returns the list of patterns produced by expanding the curly brace
+// groups of the pattern of g. See ExpandBraces for details.
+func (g *Glob) Expand() []string {
+	return ExpandBraces(g.pattern)
+}
+
+func expandBraces(pattern string) []string {
+	open, end, commas := findBraceGroup(pattern)
+	if open == -1 {
+		return []string{pattern}
+	}
+
+	prefix, suffix := pattern[:open], pattern[end+1:]
+	var expanded []string
+	if from, to, _, ok := braceRange(pattern[open+1 : end+1]); ok {
+		step := rune(1)
+		if from > to {
+			step = -1
+		}
+		for r := from; ; r += step {
+			for _, p := range joinPatterns(prefix, escapeGlobRune(r)+suffix) {
+				expanded = append(expanded, expandBraces(p)...)
+			}
+			if r == to {
+				break
+			}
+		}
+		return expanded
+	}
+	start := open + 1
+	for _, comma := range append(commas, end) {
+		for _, head := range joinPatterns(prefix, pattern[start:comma]) {
+			for _, p := range joinPatterns(head, suffix) {
+				expanded = append(expanded, expandBraces(p)...)
+			}
+		}
+		start = comma + 1
+	}
+	return expanded
+}
+
+// joinPatterns returns patterns matching the strings made of a string
+// matching left followed by a string matching right, left and right being
+// parts of a pattern separated by a brace group. Simply concatenating them
+// may turn stars ending left, and stars or slashes starting right, into
+// wildcards of another kind: "*" and "*" would make "**", and "*" and "/x"
+// would make "*/x", which matches "x".
+func joinPatterns(left, right string) []string {
+	n := trailingStars(left)
+	if n == 0 || right == "" {
+		return []string{left + right}
+	}
+	m := len(right) - len(strings.TrimLeft(right, "*"))
+	rest := right[m:]
+	switch {
+	case m == 0 && rest[0] == '/':
+		// Escaped slashes do not combine with stars.
+		return []string{left + `\/` + rest[1:]}
+	case m == 0:
+		return []string{left + right}
+	case m > 2:
+		// "**" matches anything, and absorbs the star ending left.
+		return joinPatterns(left+strings.Repeat("*", max(0, 2-n)), right[2:])
+	case strings.HasPrefix(rest, "/"):
+		// "*/" and "**/" match nothing, or a component and a slash: after a
+		// star, nothing is left, or a slash. After "**", which matches the
+		// slash already, nothing is left.
+		joined := joinPatterns(left, rest[1:])
+		if n == 1 {
+			joined = append(joined, left+strings.Repeat("*", m-1)+`\/`+rest[1:])
+		}
+		return joined
+	case n == 1 && m == 2:
+		return []string{left + right[1:]}
+	default:
+		// A single star is absorbed by the star it follows or precedes.
+		return []string{left + rest}
+	}
+}
+
+// trailingStars returns the number of unescaped stars ending pattern.
+func trailingStars(pattern string) int {
+	trimmed := strings.TrimRight(pattern, "*")
+	n := len(pattern) - len(trimmed)
+	if n > 0 && (len(trimmed)-len(strings.TrimRight(trimmed, `\`)))%2 == 1 {
+		n--
+	}
+	return n
+}
+
+// findBraceGroup returns the indices of the opening and closing braces of the
+// first balanced brace group of pattern, along with the indices of the commas
+// separating its alternatives. It returns -1 if pattern has no such group.
+func findBraceGroup(pattern string) (open, end int, commas []int) {
+	for open = 0; open < len(pattern); open++ {
+		switch pattern[open] {
+		case '\\':
+			open++
+		case '[':
+			if i := classEnd(pattern, open); i != -1 {
+				open = i - 1
+			}
+		case '{':
+			if end, commas = matchBrace(pattern, open); end != -1 {
+				return open, end, commas
+			}
+		}
+	}
+	return -1, -1, nil
+}
+
+// matchBrace returns the index of the brace closing the one at index open,
+// along with the indices of the top-level commas in between. It returns -1
+// if the brace is never closed.
+func matchBrace(pattern string, open int) (end int, commas []int) {
+	depth := 0
+	for i := open + 1; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '[':
+			if j := classEnd(pattern, i); j != -1 {
+				i = j - 1
+			}
+		case '{':
+			depth++
+		case ',':
+			if depth == 0 {
+				commas = append(commas, i)
+			}
+		case '}':
+			if depth == 0 {
+				return i, commas
+			}
+			depth--
+		}
+	}
+	return -1, nil
+}
+
+// classEnd returns the index following the end of the bracket expression
+// starting at index open of pattern, or -1 if it is unterminated.
+func classEnd(pattern string, open int) int {
+	i := open + 1
+	if i < len(pattern) && (pattern[i] == '!' || pattern[i] == '^') {
+		i++
+	}
+	for first := true; i < len(pattern); first = false {
+		switch {
+		case pattern[i] == ']' && !first:
+			return i + 1
+		case pattern[i] == '\\':
+			i += 2
+		case strings.HasPrefix(pattern[i:], "[:"), strings.HasPrefix(pattern[i:], "[="), strings.HasPrefix(pattern[i:], "[."):
+			if end := strings.Index(pattern[i+2:], pattern[i+1:i+2]+"]"); end != -1 {
+				i += 2 + end + 2
+			} else {
+				i++
+			}
+		default:
+			i++
+		}
+	}
+	return -1
+}
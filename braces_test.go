@@ -0,0 +1,92 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandBraces(t *testing.T) {
+	tcases := []struct {
+		Pattern  string
+		Expected []string
+	}{
+		{"", []string{""}},
+		{"x", []string{"x"}},
+		{"{a,b}/x", []string{"a/x", "b/x"}},
+		{"x.{c,{h,hpp}}", []string{"x.c", "x.h", "x.hpp"}},
+		{"{a,b}{1,2}", []string{"a1", "a2", "b1", "b2"}},
+		{"file{,.bak}", []string{"file", "file.bak"}},
+		{"{a}", []string{"a"}},
+		{"{}", []string{""}},
+		{`\{a,b}`, []string{`\{a,b}`}},
+		{`{a\,b,c}`, []string{`a\,b`, "c"}},
+		{"[{]{a,b}", []string{"[{]a", "[{]b"}},
+		{"[]{]{a,b}", []string{"[]{]a", "[]{]b"}},
+		{"[^]{]{a,b}", []string{"[^]{]a", "[^]{]b"}},
+		{"[[:alpha:]{]{a,b}", []string{"[[:alpha:]{]a", "[[:alpha:]{]b"}},
+		{"[[.a.]{,}]x", []string{"[[.a.]{,}]x"}},
+		{"[[=a=]{,}]{x,y}", []string{"[[=a=]{,}]x", "[[=a=]{,}]y"}},
+		{"{a,b", []string{"{a,b"}},
+		{"{a,{b,c}", []string{"{a,b", "{a,c"}},
+		{"a}b,c", []string{"a}b,c"}},
+		{"!{a,b}/**", []string{"!a/**", "!b/**"}},
+		{"shard-{a..d}", []string{"shard-a", "shard-b", "shard-c", "shard-d"}},
+		{"{c..a}{1..2}", []string{"c1", "c2", "b1", "b2", "a1", "a2"}},
+		{"{x..x}", []string{"x"}},
+		{"{Y..b}", []string{"Y", "Z", `\[`, `\\`, `\]`, "^", "_", "`", "a", "b"}},
+		{"{a..}", []string{"a.."}},
+		{"{ab..c}", []string{"ab..c"}},
+		{"*{,x}*", []string{"*", "*x*"}},
+		{"**{,x}*", []string{"**", "**x*"}},
+		{"*{,*}", []string{"*", "*"}},
+		{"x*{,**}", []string{"x*", "x**"}},
+		{"{a,*}/x", []string{"a/x", `*\/x`}},
+		{"a*{,/}x", []string{"a*x", `a*\/x`}},
+		{"*{,*/}x", []string{"*x", "*x", `*\/x`}},
+		{"{,a/}!x", []string{`\!x`, "a/!x"}},
+		{"!{,a/}!x", []string{`!\!x`, "!a/!x"}},
+		{`\*{,*}`, []string{`\*`, `\**`}},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			actual := ExpandBraces(tc.Pattern)
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+		})
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		patterns := []string{
+			"*{,x}*", "*{*,x}", "**{,*}x", "*{,**}/x", "{a,*}/x", "{a,**}/x", "a/*{,*/}x",
+			"a/*{,**/}x", "a/**{,*/}x", "*{,***/}x", "{,a/}!x", "{a..c}*{,/}", "{*,**}{*,/*}",
+		}
+		inputs := []string{"", "x", "/x", "a/x", "a/b/x", "ax", "a/", "a/bx", "b/x", "!x", "a/!x", "c/", "cx", "c/a"}
+		for _, pattern := range patterns {
+			g := MustCompileGlob(pattern)
+			for _, in := range inputs {
+				matched := false
+				for _, e := range g.Expand() {
+					matched = matched || MustCompileGlob(e).Match(in)
+				}
+				if matched != g.Match(in) {
+					t.Errorf("%s: expected %v on %q with expansions %q", pattern, g.Match(in), in, g.Expand())
+				}
+			}
+		}
+	})
+
+	t.Run("Glob", func(t *testing.T) {
+		actual := MustCompileGlob("src/{cmd,internal}/**/*.{go,s}").Expand()
+		expected := []string{"src/cmd/**/*.go", "src/cmd/**/*.s", "src/internal/**/*.go", "src/internal/**/*.s"}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("expected %q, got %q", expected, actual)
+		}
+	})
+}
@@ -0,0 +1,183 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"slices"
+	"strings"
+)
+
+// Canonical returns the pattern of g in a normalized form, which matches the
+// same strings, such that patterns differing only in form, like "{b,a}/**/**"
+// and "{a,b}/**", share the same canonical pattern:
+//
+//  - Runs of stars are collapsed, and "**" components repeated in a row are
+//    reduced to one.
+//  - The alternatives of brace groups are normalized, sorted and
+//    deduplicated, nested groups are merged into the group containing them,
+//    as in "{a,{b,c}}", and groups left with a single alternative are
+//    removed.
+//
+// Other parts of the pattern, like bracket expressions, are kept as they are.
+// If a normalization would change the strings matched, which some unusual
+// combinations of wildcards and options do, the pattern is returned as is.
+// The canonical pattern is meant to be compiled with the same options as g.
+func (g *Glob) Canonical() string {
+	in := g.opts.input()
+	pattern, neg := g.pattern, ""
+	if g.negated {
+		pattern, neg = pattern[len("!"):], "!"
+	}
+	canonical := neg + in.swap(canonicalPattern(in.swap(pattern), g.opts.CrossSeparators))
+	if canonical == g.pattern {
+		return canonical
+	}
+	c, err := CompileGlobOptions(canonical, g.opts)
+	if err != nil || !g.Equal(c) {
+		return g.pattern
+	}
+	return canonical
+}
+
+// canonicalPattern returns the canonical form of pattern, whose separator is
+// "/". If cross is true, stars match slashes.
+func canonicalPattern(pattern string, cross bool) string {
+	var segments []string
+	for _, seg := range splitPattern(pattern) {
+		seg = canonicalSegment(seg, cross)
+		if seg == "**" && !cross && len(segments) > 0 && segments[len(segments)-1] == "**" {
+			continue
+		}
+		segments = append(segments, seg)
+	}
+	return strings.Join(segments, "/")
+}
+
+// canonicalSegment returns the canonical form of a component of a pattern.
+func canonicalSegment(seg string, cross bool) string {
+	var b strings.Builder
+	for i := 0; i < len(seg); i++ {
+		switch seg[i] {
+		case '\\':
+			end := min(i+2, len(seg))
+			b.WriteString(seg[i:end])
+			i = end - 1
+		case '[':
+			end := classEnd(seg, i)
+			if end == -1 {
+				end = i + 1
+			}
+			b.WriteString(seg[i:end])
+			i = end - 1
+		case '{':
+			end, commas := matchBrace(seg, i)
+			if end == -1 {
+				b.WriteByte('{')
+				break
+			}
+			if _, _, _, ok := braceRange(seg[i+1 : end+1]); ok {
+				b.WriteString(seg[i : end+1])
+				i = end
+				break
+			}
+			var alts []string
+			start := i + 1
+			for _, comma := range append(commas, end) {
+				alts = append(alts, braceAlternatives(canonicalPattern(seg[start:comma], cross))...)
+				start = comma + 1
+			}
+			slices.Sort(alts)
+			alts = slices.Compact(alts)
+			if len(alts) == 1 {
+				b.WriteString(alts[0])
+			} else {
+				b.WriteString("{" + strings.Join(alts, ",") + "}")
+			}
+			i = end
+		case '*':
+			n := 1
+			for i+1 < len(seg) && seg[i+1] == '*' {
+				i++
+				n++
+			}
+			if n > 1 && !cross {
+				b.WriteString("**")
+			} else {
+				b.WriteByte('*')
+			}
+		default:
+			b.WriteByte(seg[i])
+		}
+	}
+	return b.String()
+}
+
+// braceAlternatives returns the alternatives of alt if it is a brace group
+// as a whole, as in "{a,b}", or alt itself otherwise.
+func braceAlternatives(alt string) []string {
+	if !strings.HasPrefix(alt, "{") {
+		return []string{alt}
+	}
+	end, commas := matchBrace(alt, 0)
+	if end != len(alt)-1 {
+		return []string{alt}
+	}
+	if _, _, _, ok := braceRange(alt[1:]); ok {
+		return []string{alt}
+	}
+	var alts []string
+	start := 1
+	for _, comma := range append(commas, end) {
+		alts = append(alts, alt[start:comma])
+		start = comma + 1
+	}
+	return alts
+}
+
+// Equal returns whether g and other match the same strings, whatever the
+// form of their patterns: "*.{go,c}" and "*.{c,go}" are equal, and so are
+// "**/**" and "**", compiled with the same options. Globs whose options
+// alter the strings matched differently, like CleanPath or Separator, are
+// never equal, and neither are a negated glob and a glob that is not. Since
+// MatchBase only applies to patterns without slashes, a glob compiled with
+// it is never equal to one whose pattern has slashes, like "**" and "**/**".
+func (g *Glob) Equal(other *Glob) bool {
+	if g.negated != other.negated || g.opts.input() != other.opts.input() {
+		return false
+	}
+	return equivalent(g.prog, other.prog)
+}
+
+// equivalent explores the product of the automata of a and b, looking for a
+// state accepted by one but not the other.
+func equivalent(a, b *program) bool {
+	type state struct {
+		a, b    []uint32
+		leading bool
+	}
+
+	alphabet := overlapAlphabet([]*program{a, b})
+	states := []state{{a: a.start(), b: b.start(), leading: true}}
+	seen := map[string]bool{stateKey(states[0].a, states[0].b, true): true}
+	for i := 0; i < len(states); i++ {
+		s := states[i]
+		if a.accepts(s.a) != b.accepts(s.b) {
+			return false
+		}
+		for _, r := range alphabet {
+			na, nb := a.next(s.a, r, s.leading), b.next(s.b, r, s.leading)
+			if len(na) == 0 && len(nb) == 0 {
+				continue
+			}
+			leading := r == '/'
+			if key := stateKey(na, nb, leading); !seen[key] {
+				seen[key] = true
+				states = append(states, state{a: na, b: nb, leading: leading})
+			}
+		}
+	}
+	return true
+}
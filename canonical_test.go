@@ -0,0 +1,114 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"testing"
+)
+
+func TestGlobCanonical(t *testing.T) {
+	tcases := []struct {
+		Pattern   string
+		Opts      GlobOptions
+		Canonical string
+	}{
+		{"main.go", GlobOptions{}, "main.go"},
+		{"{b,a}/**/**", GlobOptions{}, "{a,b}/**"},
+		{"**/**/**/x", GlobOptions{}, "**/x"},
+		{"***", GlobOptions{}, "**"},
+		{"a***b", GlobOptions{}, "a**b"},
+		{"*.{go,c,go}", GlobOptions{}, "*.{c,go}"},
+		{"{c,{b,a}}", GlobOptions{}, "{a,b,c}"},
+		{"x{a,{b,c}d}", GlobOptions{}, "x{a,{b,c}d}"},
+		{"{a,a}.go", GlobOptions{}, "a.go"},
+		{"{,}x", GlobOptions{}, "x"},
+		{"a/{b/**/**,c}", GlobOptions{}, "a/{b/**,c}"},
+		{"!{b,a}", GlobOptions{}, "!{a,b}"},
+		{`\{b,a\}`, GlobOptions{}, `\{b,a\}`},
+		{"[{]{b,a}", GlobOptions{}, "[{]{a,b}"},
+		{"{3..1}", GlobOptions{}, "{3..1}"},
+		{"[ba]", GlobOptions{}, "[ba]"},
+		{"a***/b", GlobOptions{}, "a***/b"},
+		{"{b,a}.**.**", GlobOptions{Separator: '.'}, "{a,b}.**"},
+		{"**/**", GlobOptions{CrossSeparators: true}, "*/*"},
+		{"{B,a}", GlobOptions{CaseInsensitive: true}, "{B,a}"},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, tc.Opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			canonical := g.Canonical()
+			if canonical != tc.Canonical {
+				t.Fatalf("expected %q, got %q", tc.Canonical, canonical)
+			}
+			c, err := CompileGlobOptions(canonical, tc.Opts)
+			if err != nil {
+				t.Fatalf("canonical pattern %q does not compile: %v", canonical, err)
+			}
+			if !g.Equal(c) {
+				t.Fatalf("canonical pattern %q is not equal to %q", canonical, tc.Pattern)
+			}
+			if again := c.Canonical(); again != canonical {
+				t.Fatalf("expected canonical pattern %q to be stable, got %q", canonical, again)
+			}
+		})
+	}
+}
+
+func TestGlobEqual(t *testing.T) {
+	tcases := []struct {
+		A, B  string
+		Opts  GlobOptions
+		Equal bool
+	}{
+		{"*.go", "*.go", GlobOptions{}, true},
+		{"*.{go,c}", "*.{c,go}", GlobOptions{}, true},
+		{"**/**", "**", GlobOptions{}, true},
+		{"{a,b}x", "[ab]x", GlobOptions{}, true},
+		{"{0..9}", "[[:digit:]]", GlobOptions{}, true},
+		{"?", "[!/]", GlobOptions{}, true},
+		{"*", "**", GlobOptions{}, false},
+		{"*.go", "*.c", GlobOptions{}, false},
+		{"a/**", "a/*", GlobOptions{}, false},
+		{"a/**", "a/**/*", GlobOptions{}, true},
+		{"a/**", "a/?**", GlobOptions{}, false},
+		{"*", "**", GlobOptions{CrossSeparators: true}, true},
+		{"*.GO", "*.go", GlobOptions{CaseInsensitive: true}, true},
+		{"!a", "a", GlobOptions{}, false},
+		{"*.go", "*.{go,go}", GlobOptions{MatchBase: true}, true},
+		{"**/**", "**", GlobOptions{MatchBase: true}, false},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.A+" "+tc.B, func(t *testing.T) {
+			a, err := CompileGlobOptions(tc.A, tc.Opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			b, err := CompileGlobOptions(tc.B, tc.Opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if equal := a.Equal(b); equal != tc.Equal {
+				t.Fatalf("expected %v, got %v", tc.Equal, equal)
+			}
+			if equal := b.Equal(a); equal != tc.Equal {
+				t.Fatalf("expected %v in reverse, got %v", tc.Equal, equal)
+			}
+		})
+	}
+
+	t.Run("Options", func(t *testing.T) {
+		a := MustCompileGlob("a/b")
+		b, _ := CompileGlobOptions("a/b", GlobOptions{CleanPath: true})
+		if a.Equal(b) {
+			t.Fatalf("expected globs with different options not to be equal")
+		}
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"strings"
+)
+
+// Capture returns whether path matches the glob pattern, like Match, and if
+// so, the substrings of path matched by each wildcard and brace group of the
+// pattern, in the order they appear in it. For instance, capturing
+// "src/**/*.{c,h}" on "src/a/b/x.h" returns "a/b", "x" and "h".
+//
+// "*", "**", "?", bracket expressions, brace groups and brace ranges are all
+// captured. A "**/" matching no directory, as well as a group within the
+// alternative of a brace group that did not match, captures "". When several
+// captures are possible, stars match as many characters as they can, from
+// left to right, and brace groups prefer their first alternatives.
+//
+// Captures are substrings of path as matched, i.e. after the transformations
+// of the CleanPath and MatchBase options.
+func (g *Glob) Capture(path string) ([]string, bool) {
+	in := g.opts.input()
+	data := in.prepare(path, '/')
+	slots := g.prog.capture(data)
+	if slots == nil && in.trailingSlash {
+		if trimmed := strings.TrimSuffix(data, "/"); trimmed != data {
+			slots = g.prog.capture(trimmed)
+		} else {
+			// Positions past the end of data can only be that of the slash.
+			slots = g.prog.capture(data + "/")
+			for i, pos := range slots {
+				slots[i] = min(pos, len(data))
+			}
+		}
+	}
+
+	countMatch(g.metrics, slots != nil)
+	if slots == nil {
+		return nil, false
+	}
+
+	captures := make([]string, g.prog.ncap)
+	for i := range captures {
+		if start, end := slots[2*i], slots[2*i+1]; start != -1 && end != -1 {
+			captures[i] = in.swap(data[start:end])
+		}
+	}
+	return captures, true
+}
+
+// capList is a list of threads, each with its own capture slots.
+type capList struct {
+	threadList
+
+	// caps holds the capture slots of the thread at each instruction.
+	caps [][]int
+}
+
+func (l *capList) reset(n int) {
+	l.threadList.reset(n)
+	if cap(l.caps) < n {
+		l.caps = make([][]int, n)
+	}
+	l.caps = l.caps[:n]
+}
+
+// add adds the thread at pc with the capture slots caps to l, following
+// jumps, splits and saves. pos is the current position in the input.
+func (l *capList) add(prog *program, pc int, caps []int, pos int) {
+	if l.contains(pc) {
+		return
+	}
+	l.insert(pc)
+	switch i := &prog.insts[pc]; i.op {
+	case instJmp:
+		l.add(prog, i.x, caps, pos)
+	case instSplit:
+		l.add(prog, i.x, caps, pos)
+		l.add(prog, i.y, caps, pos)
+	case instSave:
+		saved := append([]int(nil), caps...)
+		saved[i.x] = pos
+		l.add(prog, pc+1, saved, pos)
+	default:
+		l.caps[pc] = caps
+	}
+}
+
+// capture runs the program over s, tracking the positions of capture
+// groups. It returns the capture slots of the match, or nil if s does not
+// match. Threads are kept in order of priority, such that the first one to
+// match has the expected captures.
+func (prog *program) capture(s string) []int {
+	if prog.rejects(s) {
+		return nil
+	}
+	var clist, nlist capList
+	clist.reset(len(prog.insts))
+	nlist.reset(len(prog.insts))
+
+	caps := make([]int, 2*prog.ncap)
+	for i := range caps {
+		caps[i] = -1
+	}
+	clist.add(prog, 0, caps, 0)
+
+	leading := true
+	for pos := 0; pos < len(s); {
+		r, size := decodeRune(s[pos:], prog.utf8)
+		nlist.dense = nlist.dense[:0]
+		for _, pc := range clist.dense {
+			if prog.insts[pc].matches(r, false, leading) {
+				nlist.add(prog, int(pc)+1, clist.caps[pc], pos+size)
+			}
+		}
+		leading = r == '/'
+		pos += size
+		clist, nlist = nlist, clist
+		if len(clist.dense) == 0 {
+			return nil
+		}
+	}
+	for _, pc := range clist.dense {
+		if prog.insts[pc].op == instMatch {
+			return clist.caps[pc]
+		}
+	}
+	return nil
+}
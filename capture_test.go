@@ -0,0 +1,60 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGlobCapture(t *testing.T) {
+	tcases := []struct {
+		Pattern  string
+		Options  GlobOptions
+		Path     string
+		Captures []string
+	}{
+		{Pattern: "main.go", Path: "main.go", Captures: []string{}},
+		{Pattern: "*.go", Path: "main.go", Captures: []string{"main"}},
+		{Pattern: "src/**/*.{c,h}", Path: "src/a/b/x.h", Captures: []string{"a/b", "x", "h"}},
+		{Pattern: "src/**/*.{c,h}", Path: "src/x.c", Captures: []string{"", "x", "c"}},
+		{Pattern: "src/**", Path: "src/a/b", Captures: []string{"a/b"}},
+		{Pattern: "file?.[ch]", Path: "file1.c", Captures: []string{"1", "c"}},
+		{Pattern: "shard-{a..c}", Path: "shard-b", Captures: []string{"b"}},
+		{Pattern: "{a,ab}*", Path: "abc", Captures: []string{"a", "bc"}},
+		{Pattern: "*-*", Path: "a-b-c", Captures: []string{"a-b", "c"}},
+		{Pattern: "{x{1,2},y}/*", Path: "x2/z", Captures: []string{"x2", "2", "z"}},
+		{Pattern: "{x{1,2},y}/*", Path: "y/z", Captures: []string{"y", "", "z"}},
+		{Pattern: "*/", Path: "dir/", Captures: []string{"dir"}},
+		{Pattern: "ünï/*", Path: "ünï/çödé", Captures: []string{"çödé"}},
+		{Pattern: "*.GO", Options: GlobOptions{CaseInsensitive: true}, Path: "Main.go", Captures: []string{"Main"}},
+		{Pattern: "dir/*", Options: GlobOptions{CleanPath: true}, Path: "./dir//file", Captures: []string{"file"}},
+		{Pattern: "*.go", Options: GlobOptions{MatchBase: true}, Path: "cmd/main.go", Captures: []string{"main"}},
+		{Pattern: "b?", Options: GlobOptions{Unanchored: true}, Path: "abcd", Captures: []string{"c"}},
+		{Pattern: "d*", Options: GlobOptions{TrailingSlash: true}, Path: "dir/", Captures: []string{"ir"}},
+		{Pattern: "d*/", Options: GlobOptions{TrailingSlash: true}, Path: "dir", Captures: []string{"ir"}},
+
+		{Pattern: "*.go", Path: "main.c"},
+		{Pattern: "*.go", Path: "cmd/main.go"},
+		{Pattern: "*.go", Options: GlobOptions{Period: true}, Path: ".main.go"},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern+" "+tc.Path, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, tc.Options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			captures, ok := g.Capture(tc.Path)
+			if ok != (tc.Captures != nil) || ok != g.Match(tc.Path) {
+				t.Fatalf("expected match %v, got %v", tc.Captures != nil, ok)
+			}
+			if !reflect.DeepEqual(captures, tc.Captures) {
+				t.Fatalf("expected captures %q, got %q", tc.Captures, captures)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+// MinDepth returns the minimum number of components of the paths matching
+// the pattern, as counted by WalkMaxDepth: "*.go" and "src/**" match paths
+// of at least 1 component, while "src/*/*.go" matches paths of at least 2,
+// since "*/" may match nothing. A trailing slash is not a component of its
+// own.
+func (g *Glob) MinDepth() int {
+	lo, _ := g.depthRange()
+	return lo
+}
+
+// MaxDepth returns the maximum number of components of the paths matching
+// the pattern, or -1 if it is unbounded, as with "**". Along with MinDepth,
+// it allows choosing between reading a single directory and walking a whole
+// tree: patterns whose MaxDepth is 1 only match entries of the root.
+func (g *Glob) MaxDepth() int {
+	_, hi := g.depthRange()
+	if hi >= unboundedDepth {
+		return -1
+	}
+	return hi
+}
+
+// unboundedDepth stands for an unbounded number of slashes.
+const unboundedDepth = 1 << 30
+
+// slashRange is the range of the number of slashes in a set of strings. The
+// zero value, whose valid field is false, is the empty set.
+type slashRange struct {
+	lo, hi int
+	valid  bool
+}
+
+func (r slashRange) union(o slashRange) slashRange {
+	switch {
+	case !r.valid:
+		return o
+	case !o.valid:
+		return r
+	}
+	return slashRange{lo: min(r.lo, o.lo), hi: max(r.hi, o.hi), valid: true}
+}
+
+func (r slashRange) add(lo, hi int) slashRange {
+	if !r.valid {
+		return r
+	}
+	return slashRange{lo: r.lo + lo, hi: min(r.hi+hi, unboundedDepth), valid: true}
+}
+
+// slashStates holds the ranges of the number of slashes in the strings
+// matched by a sequence of nodes, split by whether they end with a slash.
+type slashStates struct {
+	slash, other slashRange
+}
+
+func (s slashStates) union(o slashStates) slashStates {
+	return slashStates{slash: s.slash.union(o.slash), other: s.other.union(o.other)}
+}
+
+// any returns the range of all the strings.
+func (s slashStates) any() slashRange {
+	return s.slash.union(s.other)
+}
+
+// depthRange returns the minimum and maximum depth of the paths matching
+// the pattern, the maximum being at least unboundedDepth if unbounded.
+func (g *Glob) depthRange() (lo, hi int) {
+	if g.negated {
+		return 0, unboundedDepth
+	}
+	nodes := g.nodes
+	if g.opts.Unanchored {
+		star := node{op: nodeStar, class: anyRune}
+		nodes = append(append([]node{star}, nodes...), star)
+	}
+	states := slashesOf(slashStates{other: slashRange{valid: true}}, nodes)
+
+	// Paths ending with a slash have as many components as slashes.
+	lo, hi = unboundedDepth, 0
+	if r := states.slash; r.valid {
+		lo, hi = r.lo, r.hi
+	}
+	if r := states.other; r.valid {
+		lo, hi = min(lo, r.lo+1), max(hi, min(r.hi+1, unboundedDepth))
+	}
+	if g.opts.MatchBase {
+		hi = unboundedDepth
+	}
+	return max(lo, 0), hi
+}
+
+// slashesOf returns the states of the strings made of a string of states
+// followed by a string matching nodes.
+func slashesOf(states slashStates, nodes []node) slashStates {
+	for _, n := range nodes {
+		all := states.any()
+		switch n.op {
+		case nodeRune:
+			if n.r == '/' {
+				states = slashStates{slash: all.add(1, 1)}
+			} else {
+				states = slashStates{other: all}
+			}
+		case nodeClass:
+			states = classSlashes(all, n.class, 1)
+		case nodeStar:
+			states = states.union(classSlashes(all, n.class, unboundedDepth))
+		case nodeAlt:
+			var alts slashStates
+			for _, alt := range n.alts {
+				alts = alts.union(slashesOf(states, alt))
+			}
+			states = alts
+		}
+	}
+	return states
+}
+
+// classSlashes returns the states of the strings made of a string of range r
+// followed by 1 to n runes of c.
+func classSlashes(r slashRange, c *charClass, n int) slashStates {
+	var states slashStates
+	slash := c.matches('/')
+	if slash {
+		states.slash = r.add(1, n)
+	}
+	other := c.negated
+	for _, rg := range c.ranges {
+		other = other || rg.lo != '/' || rg.hi != '/'
+	}
+	if other {
+		// Strings ending with another rune may have slashes before it.
+		if slash {
+			states.other = r.add(0, n-1)
+		} else {
+			states.other = r
+		}
+	}
+	return states
+}
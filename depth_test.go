@@ -0,0 +1,53 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"testing"
+)
+
+func TestGlobDepth(t *testing.T) {
+	tcases := []struct {
+		Pattern  string
+		Opts     GlobOptions
+		Min, Max int
+	}{
+		{"main.go", GlobOptions{}, 1, 1},
+		{"*.go", GlobOptions{}, 1, 1},
+		{"src/main.go", GlobOptions{}, 2, 2},
+		{"src/*/*.go", GlobOptions{}, 2, 3},
+		{"src/**", GlobOptions{}, 1, -1},
+		{"src/**/*.go", GlobOptions{}, 2, -1},
+		{"**/*.go", GlobOptions{}, 1, -1},
+		{"src/", GlobOptions{}, 1, 1},
+		{"src/*/", GlobOptions{}, 1, 2},
+		{"a/**/", GlobOptions{}, 1, -1},
+		{"{a,b/c,d/e/f}", GlobOptions{}, 1, 3},
+		{"a?b", GlobOptions{}, 1, 1},
+		{"a?b", GlobOptions{CrossSeparators: true}, 1, 2},
+		{"a*", GlobOptions{CrossSeparators: true}, 1, -1},
+		{"a[!x]", GlobOptions{}, 1, 1},
+		{"a[!x]b", GlobOptions{}, 1, 2},
+		{"*.go", GlobOptions{MatchBase: true}, 1, -1},
+		{"a/b", GlobOptions{Unanchored: true}, 2, -1},
+		{"!a/b", GlobOptions{}, 0, -1},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, tc.Opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if min := g.MinDepth(); min != tc.Min {
+				t.Errorf("expected min depth %d, got %d", tc.Min, min)
+			}
+			if max := g.MaxDepth(); max != tc.Max {
+				t.Errorf("expected max depth %d, got %d", tc.Max, max)
+			}
+		})
+	}
+}
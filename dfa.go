@@ -0,0 +1,203 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"encoding/binary"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// The programs of sets, which combine thousands of patterns, are run as
+// deterministic automata built lazily, as in RE2: each state of a dfa is a
+// set of threads of the machine, and the transitions between states are
+// computed once, the first time they are taken. Matching then costs a
+// lookup per rune, whatever the number of patterns.
+
+// maxDFAStates bounds the number of states a dfa caches. Past it, new states
+// are computed for each transition taken, which is slower, but still
+// correct.
+const maxDFAStates = 4096
+
+// dfa runs a program, whose match instructions hold the index of the pattern
+// they match, as a deterministic automaton. It is safe for concurrent use.
+type dfa struct {
+	prog  *program
+	start *dfaState
+
+	// mu guards states, and the transitions on non-ASCII runes.
+	mu     sync.Mutex
+	states map[string]*dfaState
+}
+
+// dfaState is a set of threads of the machine, and whether the next rune
+// starts a path component.
+type dfaState struct {
+	pcs     []uint32
+	leading bool
+
+	// last is the highest index of the patterns matched in this state, or
+	// -1 if there is none.
+	last int
+
+	// cached is set if the state is part of the states of the dfa, in
+	// which case its transitions are cached.
+	cached bool
+
+	ascii [utf8.RuneSelf]atomic.Pointer[dfaState]
+	other map[rune]*dfaState
+}
+
+func newDFA(prog *program) *dfa {
+	d := &dfa{prog: prog, states: make(map[string]*dfaState)}
+	m := prog.machine(false)
+	defer m.release()
+	d.start = d.state(m.clist.dense, m.leading)
+	return d
+}
+
+// state returns the state made of the threads pcs. d.mu must be held once
+// the dfa is shared.
+func (d *dfa) state(pcs []uint32, leading bool) *dfaState {
+	pcs = slices.Clone(pcs)
+	slices.Sort(pcs)
+	key := make([]byte, 1, 1+4*len(pcs))
+	if leading {
+		key[0] = 1
+	}
+	for _, pc := range pcs {
+		key = binary.LittleEndian.AppendUint32(key, pc)
+	}
+	if s, ok := d.states[string(key)]; ok {
+		return s
+	}
+
+	s := &dfaState{pcs: pcs, leading: leading, last: -1}
+	for _, pc := range pcs {
+		if i := &d.prog.insts[pc]; i.op == instMatch && i.x > s.last {
+			s.last = i.x
+		}
+	}
+	if len(d.states) < maxDFAStates {
+		s.cached = true
+		d.states[string(key)] = s
+	}
+	return s
+}
+
+// next returns the state following s over r.
+func (d *dfa) next(s *dfaState, r rune) *dfaState {
+	if r >= 0 && r < utf8.RuneSelf {
+		if n := s.ascii[r].Load(); n != nil {
+			return n
+		}
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if n, ok := s.other[r]; ok {
+		return n
+	}
+
+	m := d.prog.machine(false)
+	m.clist.dense = append(m.clist.dense[:0], s.pcs...)
+	m.leading = s.leading
+	m.step(r)
+	n := d.state(m.clist.dense, m.leading)
+	m.release()
+
+	switch {
+	case !s.cached || !n.cached:
+	case r >= 0 && r < utf8.RuneSelf:
+		s.ascii[r].Store(n)
+	default:
+		if s.other == nil {
+			s.other = make(map[rune]*dfaState)
+		}
+		s.other[r] = n
+	}
+	return n
+}
+
+// last returns the highest index of the patterns of the program matching s,
+// or -1 if none does. mode.fold is not supported.
+func (d *dfa) last(s string, mode matchMode) int {
+	sep := mode.sep
+	if sep == 0 {
+		sep = '/'
+	}
+	if d.prog.rejects(s) {
+		return -1
+	}
+	st, last := d.start, -1
+	slash := false
+	for i := 0; i < len(s); {
+		r, n := rune(s[i]), 1
+		if r >= utf8.RuneSelf {
+			r, n = decodeRune(s[i:], d.prog.utf8)
+		}
+		if r == sep {
+			r = '/'
+		}
+		if mode.trailingSlash && r == '/' && i == len(s)-1 {
+			last = st.last
+		}
+		if st = d.next(st, r); len(st.pcs) == 0 {
+			return last
+		}
+		slash = r == '/'
+		i += n
+	}
+	last = max(last, st.last)
+	if mode.trailingSlash && !slash {
+		last = max(last, d.next(st, '/').last)
+	}
+	return last
+}
+
+// all returns the indices of the patterns of the program matching s, in
+// increasing order. mode.fold is not supported.
+func (d *dfa) all(s string, mode matchMode) []int {
+	sep := mode.sep
+	if sep == 0 {
+		sep = '/'
+	}
+	if d.prog.rejects(s) {
+		return nil
+	}
+	// ends are the states whose matches are those of s.
+	var ends []*dfaState
+	st := d.start
+	slash := false
+	for i := 0; i < len(s) && len(st.pcs) != 0; {
+		r, n := decodeRune(s[i:], d.prog.utf8)
+		if r == sep {
+			r = '/'
+		}
+		if mode.trailingSlash && r == '/' && i == len(s)-1 {
+			ends = append(ends, st)
+		}
+		st = d.next(st, r)
+		slash = r == '/'
+		i += n
+	}
+	ends = append(ends, st)
+	if mode.trailingSlash && !slash && len(st.pcs) != 0 {
+		ends = append(ends, d.next(st, '/'))
+	}
+
+	var indices []int
+	for _, end := range ends {
+		for _, pc := range end.pcs {
+			if i := &d.prog.insts[pc]; i.op == instMatch {
+				indices = append(indices, i.x)
+			}
+		}
+	}
+	slices.Sort(indices)
+	return slices.Compact(indices)
+}
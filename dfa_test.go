@@ -0,0 +1,89 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestDFA(t *testing.T) {
+	patterns := []string{
+		"*.go", "src/**/*_test.go", "docs/{a,b}/*.md", "[!a-z]*", "**/.git/**",
+		"build/", "a?c/**", "héllo/*", "**/vendor", "src/*/gen/*.pb.go",
+	}
+	inputs := []string{
+		"", "main.go", "src/a/b_test.go", "src/a/b.go", "docs/a/x.md", "docs/c/x.md",
+		"README", "x/.git/config", ".git/HEAD", "build/", "build", "abc/d", "abc",
+		"héllo/wörld", "a/b/vendor", "src/x/gen/y.pb.go", "src/x/y/gen/z.pb.go",
+	}
+
+	globs, err := CompileGlobs(patterns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := newDFA(combine(globs))
+	for _, input := range inputs {
+		expected := -1
+		for i, g := range globs {
+			if g.Match(input) {
+				expected = i
+			}
+		}
+		if last := d.last(input, matchMode{}); last != expected {
+			t.Errorf("%q: expected pattern %d, got %d", input, expected, last)
+		}
+	}
+
+	t.Run("TrailingSlash", func(t *testing.T) {
+		opts := GlobOptions{TrailingSlash: true}
+		globs := []*Glob{}
+		for _, pattern := range []string{"dir", "src/", "*.go"} {
+			g, err := CompileGlobOptions(pattern, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			globs = append(globs, g)
+		}
+		d := newDFA(combine(globs))
+		for input, expected := range map[string]int{
+			"dir": 0, "dir/": 0, "src": 1, "src/": 1, "a.go": 2, "a.go/": 2, "x": -1,
+		} {
+			if last := d.last(input, opts.input().mode('/', false)); last != expected {
+				t.Errorf("%q: expected pattern %d, got %d", input, expected, last)
+			}
+		}
+	})
+
+	t.Run("Full", func(t *testing.T) {
+		d := newDFA(combine(globs))
+		for i := len(d.states); i < maxDFAStates; i++ {
+			d.states[strconv.Itoa(i)] = &dfaState{}
+		}
+		for _, input := range inputs {
+			if last, expected := d.last(input, matchMode{}), newDFA(combine(globs)).last(input, matchMode{}); last != expected {
+				t.Errorf("%q: expected pattern %d, got %d", input, expected, last)
+			}
+		}
+		if len(d.states) != maxDFAStates {
+			t.Errorf("expected at most %d states, got %d", maxDFAStates, len(d.states))
+		}
+	})
+}
+
+func BenchmarkGlobSetMatchLarge(b *testing.B) {
+	var patterns []string
+	for i := 0; i < 5000; i++ {
+		patterns = append(patterns, fmt.Sprintf("**/dir%d/*.ext%d", i, i%10))
+	}
+	set := MustCompileGlobSet(patterns)
+	path := "src/foo/bar/dir4999/baz.ext9"
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		set.Match(path)
+	}
+}
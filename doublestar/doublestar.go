@@ -0,0 +1,177 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+// Package doublestar provides the most common functions of
+// github.com/bmatcuk/doublestar/v4, with the same signatures, implemented
+// with shutil globs. Code using these functions can be migrated by changing
+// its import path.
+//
+// Patterns follow the syntax of shutil.Glob, with one exception: as in
+// doublestar, a leading "!" is a literal character rather than a negation.
+package doublestar
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"barney.ci/shutil"
+)
+
+// ErrBadPattern is returned, possibly wrapped, when a pattern is malformed.
+var ErrBadPattern = path.ErrBadPattern
+
+func compile(pattern string) (*shutil.Glob, error) {
+	if strings.HasPrefix(pattern, "!") {
+		pattern = `\` + pattern
+	}
+	g, err := shutil.CompileGlob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBadPattern, err)
+	}
+	return g, nil
+}
+
+// ValidatePattern returns whether s is a valid pattern.
+func ValidatePattern(s string) bool {
+	_, err := compile(s)
+	return err == nil
+}
+
+// ValidatePathPattern is like ValidatePattern, but s uses the path separator
+// of the operating system.
+func ValidatePathPattern(s string) bool {
+	return ValidatePattern(filepath.ToSlash(s))
+}
+
+// Match returns whether name matches pattern. Both use "/" as a path
+// separator. The only possible error is ErrBadPattern.
+func Match(pattern, name string) (bool, error) {
+	g, err := compile(pattern)
+	if err != nil {
+		return false, err
+	}
+	return g.Match(name), nil
+}
+
+// MatchUnvalidated is like Match, but returns false if the pattern is
+// malformed.
+func MatchUnvalidated(pattern, name string) bool {
+	match, _ := Match(pattern, name)
+	return match
+}
+
+// PathMatch is like Match, but pattern and name use the path separator of
+// the operating system. On Windows, backslashes are thus separators, and
+// cannot be used to escape special characters.
+func PathMatch(pattern, name string) (bool, error) {
+	g, err := compile(filepath.ToSlash(pattern))
+	if err != nil {
+		return false, err
+	}
+	return g.MatchPath(name), nil
+}
+
+type globConfig struct {
+	filesOnly             bool
+	failOnPatternNotExist bool
+}
+
+// A GlobOption alters the behaviour of Glob and FilepathGlob.
+type GlobOption func(*globConfig)
+
+// WithFailOnIOErrors makes Glob fail on I/O errors. This is always the case
+// here, and the option only exists for compatibility.
+func WithFailOnIOErrors() GlobOption {
+	return func(*globConfig) {}
+}
+
+// WithFailOnPatternNotExist makes Glob return fs.ErrNotExist if the
+// directory preceding the first special character of the pattern does not
+// exist (see shutil.SplitPattern).
+func WithFailOnPatternNotExist() GlobOption {
+	return func(cfg *globConfig) {
+		cfg.failOnPatternNotExist = true
+	}
+}
+
+// WithFilesOnly makes Glob only return files, excluding directories.
+func WithFilesOnly() GlobOption {
+	return func(cfg *globConfig) {
+		cfg.filesOnly = true
+	}
+}
+
+// WithNoFollow makes Glob not follow symbolic links. Since fs.FS
+// directories are walked with fs.ReadDir, symbolic links are never followed,
+// and the option only exists for compatibility.
+func WithNoFollow() GlobOption {
+	return func(*globConfig) {}
+}
+
+// Glob returns the paths of fsys matching pattern, in lexical order, or nil
+// if there are none. See shutil.Glob.Walk for details.
+func Glob(fsys fs.FS, pattern string, opts ...GlobOption) ([]string, error) {
+	var cfg globConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	g, err := compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.failOnPatternNotExist {
+		if dir, _ := shutil.SplitPattern(g.String()); dir != "/" {
+			if _, err := fs.Stat(fsys, dir); errors.Is(err, fs.ErrNotExist) {
+				return nil, err
+			}
+		}
+	}
+
+	var walkOpts []shutil.WalkOption
+	if cfg.filesOnly {
+		walkOpts = append(walkOpts, shutil.WalkTypes(shutil.TypeFile|shutil.TypeSymlink|shutil.TypeOther))
+	}
+	matches, err := g.Walk(fsys, walkOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk also returns the directories whose path followed by "/" matches,
+	// like "cmd" for "cmd/*". Only keep them if the pattern ends with "/".
+	if strings.HasSuffix(pattern, "/") {
+		return matches, nil
+	}
+	filtered := matches[:0]
+	for _, match := range matches {
+		if g.Match(match) {
+			filtered = append(filtered, match)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, nil
+	}
+	return filtered, nil
+}
+
+// FilepathGlob is like Glob, but walks the filesystem of the operating
+// system. pattern and the returned paths use its path separator, and are
+// relative to the current directory unless pattern is absolute.
+func FilepathGlob(pattern string, opts ...GlobOption) ([]string, error) {
+	dir, rest := shutil.SplitPattern(filepath.ToSlash(filepath.Clean(pattern)))
+	dir = filepath.FromSlash(dir)
+	matches, err := Glob(os.DirFS(dir), rest, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for i, match := range matches {
+		matches[i] = filepath.Join(dir, filepath.FromSlash(match))
+	}
+	return matches, nil
+}
@@ -0,0 +1,137 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package doublestar
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMatch(t *testing.T) {
+	tcases := []struct {
+		Pattern, Name string
+		Match         bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "cmd/main.go", false},
+		{"**/*.go", "cmd/main.go", true},
+		{"src/{a,b}/*", "src/b/x", true},
+		{"!x", "!x", true},
+		{"!x", "y", false},
+		{"[!a]", "b", true},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			match, err := Match(tc.Pattern, tc.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if match != tc.Match {
+				t.Fatalf("expected %v, got %v", tc.Match, match)
+			}
+			if MatchUnvalidated(tc.Pattern, tc.Name) != tc.Match {
+				t.Fatalf("expected MatchUnvalidated to return %v", tc.Match)
+			}
+			if !ValidatePattern(tc.Pattern) {
+				t.Fatal("expected pattern to be valid")
+			}
+		})
+	}
+
+	for _, pattern := range []string{"[a", "{a,b", "a}"} {
+		if ValidatePattern(pattern) {
+			t.Errorf("expected %q to be invalid", pattern)
+		}
+		if _, err := Match(pattern, "a"); !errors.Is(err, ErrBadPattern) {
+			t.Errorf("expected ErrBadPattern for %q, got %v", pattern, err)
+		}
+		if MatchUnvalidated(pattern, "a") {
+			t.Errorf("expected %q not to match", pattern)
+		}
+	}
+}
+
+func TestPathMatch(t *testing.T) {
+	pattern := filepath.Join("src", "**", "*.go")
+	match, err := PathMatch(pattern, filepath.Join("src", "cmd", "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !match {
+		t.Fatalf("expected %q to match", pattern)
+	}
+	if !ValidatePathPattern(pattern) {
+		t.Fatalf("expected %q to be valid", pattern)
+	}
+}
+
+func TestGlob(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.go":          {},
+		"cmd/tool/main.go": {},
+		"cmd/tool/doc.md":  {},
+		"pkg.go/x":         {},
+	}
+
+	tcases := []struct {
+		Pattern  string
+		Options  []GlobOption
+		Expected []string
+	}{
+		{"*.go", nil, []string{"main.go", "pkg.go"}},
+		{"*.go", []GlobOption{WithFilesOnly()}, []string{"main.go"}},
+		{"**/main.go", nil, []string{"cmd/tool/main.go", "main.go"}},
+		{"cmd/*", []GlobOption{WithFailOnIOErrors(), WithNoFollow()}, []string{"cmd/tool"}},
+		{"*.c", nil, nil},
+		{"pkg.go/*", []GlobOption{WithFilesOnly()}, []string{"pkg.go/x"}},
+		{"pkg.go", []GlobOption{WithFilesOnly()}, nil},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			matches, err := Glob(fsys, tc.Pattern, tc.Options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(matches, tc.Expected) {
+				t.Fatalf("expected %q, got %q", tc.Expected, matches)
+			}
+		})
+	}
+
+	if _, err := Glob(fsys, "missing/*.go", WithFailOnPatternNotExist()); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist, got %v", err)
+	}
+	if _, err := Glob(fsys, "[a"); !errors.Is(err, ErrBadPattern) {
+		t.Errorf("expected ErrBadPattern, got %v", err)
+	}
+}
+
+func TestFilepathGlob(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.go", "b.txt", filepath.Join("sub", "c.go")} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	matches, err := FilepathGlob(filepath.Join(dir, "**", "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{filepath.Join(dir, "a.go"), filepath.Join(dir, "sub", "c.go")}
+	if !reflect.DeepEqual(matches, expected) {
+		t.Fatalf("expected %q, got %q", expected, matches)
+	}
+}
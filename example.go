@@ -0,0 +1,138 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"strings"
+)
+
+// exampleRunes are the runes examples are preferably made of, most readable
+// first.
+const exampleRunes = "abcdefghijklmnopqrstuvwxyz0123456789_-ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// maxExampleTries bounds the number of candidates Examples considers, which
+// grows exponentially with the number of wildcards of the pattern.
+const maxExampleTries = 10000
+
+// Example returns a string matching the glob pattern, or false if no string
+// matches it. It is the first of the strings returned by Examples.
+func (g *Glob) Example() (string, bool) {
+	examples := g.Examples(1)
+	if len(examples) == 0 {
+		return "", false
+	}
+	return examples[0], true
+}
+
+// Examples returns up to n distinct strings matching the glob pattern, which
+// helps testing rule files, or showing what a pattern means. For instance,
+// the examples of "src/**/*.{c,h}" start with "src/a.c", "src/a.h" and
+// "src/.c".
+//
+// Examples are synthesized from the pattern: wildcards are replaced with
+// nothing, or with a few readable runes, and each alternative of brace
+// groups is used in turn. Only the strings for which Match returns true are
+// returned, such that a pattern may have fewer examples than it matches
+// strings. As with Match, the "!" of negated patterns is ignored.
+func (g *Glob) Examples(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+	var out []string
+	seen := make(map[string]bool)
+	in := g.opts.input()
+	tries := 0
+	examples("", g.nodes, func(s string) bool {
+		tries++
+		s = in.swap(s)
+		if !seen[s] && g.matches(s, '/', false) {
+			seen[s] = true
+			out = append(out, s)
+		}
+		return len(out) < n && tries < maxExampleTries
+	})
+	return out
+}
+
+// examples calls then with prefix followed by each string synthesized from
+// nodes, until it returns false. It returns false if then did.
+func examples(prefix string, nodes []node, then func(string) bool) bool {
+	if len(nodes) == 0 {
+		return then(prefix)
+	}
+	n, rest := nodes[0], nodes[1:]
+	next := func(s string) bool {
+		return examples(s, rest, then)
+	}
+	switch n.op {
+	case nodeRune:
+		return next(prefix + encodeRune(n.r))
+	case nodeClass:
+		for _, r := range classSamples(n.class, 2) {
+			if !next(prefix + encodeRune(r)) {
+				return false
+			}
+		}
+	case nodeStar:
+		for _, fill := range starFills(n.class) {
+			if !next(prefix + fill) {
+				return false
+			}
+		}
+	case nodeAlt:
+		for _, alt := range n.alts {
+			if !examples(prefix, alt, next) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// classSamples returns up to n runes matched by c, preferring readable ones.
+func classSamples(c *charClass, n int) []rune {
+	var samples []rune
+	for _, r := range exampleRunes {
+		if len(samples) == n {
+			return samples
+		}
+		if c.matches(r) {
+			samples = append(samples, r)
+		}
+	}
+	if !c.negated {
+		for _, rg := range c.ranges {
+			for r := rg.lo; r <= rg.hi && len(samples) < n; r++ {
+				if !strings.ContainsRune(exampleRunes, r) {
+					samples = append(samples, r)
+				}
+			}
+		}
+		return samples
+	}
+	for r := rune(' '); r < 0x3000 && len(samples) < n; r++ {
+		if c.matches(r) && !strings.ContainsRune(exampleRunes, r) {
+			samples = append(samples, r)
+		}
+	}
+	return samples
+}
+
+// starFills returns the strings used in examples in place of a star matching
+// runes of c: a single rune first, which reads best, then nothing, and then
+// longer strings, spanning path components if c matches "/".
+func starFills(c *charClass) []string {
+	samples := classSamples(c, 2)
+	if len(samples) == 0 {
+		return []string{""}
+	}
+	first, last := string(samples[0]), string(samples[len(samples)-1])
+	fills := []string{first, "", first + last}
+	if c.matches('/') && samples[0] != '/' && samples[len(samples)-1] != '/' {
+		fills = append(fills, first+"/"+last)
+	}
+	return fills
+}
@@ -0,0 +1,63 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGlobExamples(t *testing.T) {
+	tcases := []struct {
+		Pattern  string
+		Opts     GlobOptions
+		N        int
+		Examples []string
+	}{
+		{"main.go", GlobOptions{}, 3, []string{"main.go"}},
+		{"*.go", GlobOptions{}, 2, []string{"a.go", ".go"}},
+		{"*.go", GlobOptions{}, 5, []string{"a.go", ".go", "ab.go"}},
+		{"src/**/*.{c,h}", GlobOptions{}, 4, []string{"src/a.c", "src/a.h", "src/.c", "src/.h"}},
+		{"**", GlobOptions{}, 5, []string{"a", "", "ab", "a/b"}},
+		{"[[:digit:]]?", GlobOptions{}, 3, []string{"0a", "0b", "1a"}},
+		{"[!a-z]", GlobOptions{}, 2, []string{"0", "1"}},
+		{"[é-ê]", GlobOptions{}, 3, []string{"é", "ê"}},
+		{"{0..9}x", GlobOptions{}, 2, []string{"0x", "1x"}},
+		{"!a/b", GlobOptions{}, 1, []string{"a/b"}},
+		{"*.go", GlobOptions{Period: true}, 2, []string{"a.go", ".go"}},
+		{"?*", GlobOptions{Period: true}, 2, []string{"aa", "a"}},
+		{"a.*", GlobOptions{Separator: '.'}, 3, []string{"a.a", "a.", "a.ab"}},
+		{"**/x", GlobOptions{SkipHidden: true}, 3, []string{"x", "a/x", "/x"}},
+		{"a/./b", GlobOptions{CleanPath: true}, 1, nil},
+		{"*.go", GlobOptions{}, 0, nil},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, tc.Opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			examples := g.Examples(tc.N)
+			if !reflect.DeepEqual(examples, tc.Examples) {
+				t.Fatalf("expected %q, got %q", tc.Examples, examples)
+			}
+			for _, example := range examples {
+				if !g.Match(example) {
+					t.Errorf("example %q does not match", example)
+				}
+			}
+
+			example, ok := g.Example()
+			if ok != (len(tc.Examples) > 0) && tc.N > 0 {
+				t.Fatalf("expected Example to return %v, got %v", len(tc.Examples) > 0, ok)
+			}
+			if ok && example != g.Examples(1)[0] {
+				t.Fatalf("expected Example %q, got %q", g.Examples(1)[0], example)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrNoMatch = errors.New("no match")
+)
+
+type expandConfig struct {
+	nullGlob, failGlob, noGlob bool
+}
+
+// An ExpandOption alters the behaviour of ExpandArgs.
+type ExpandOption func(*expandConfig)
+
+// ExpandNullGlob makes patterns that match no file expand to nothing, like
+// bash's nullglob option.
+func ExpandNullGlob() ExpandOption {
+	return func(cfg *expandConfig) {
+		cfg.nullGlob = true
+	}
+}
+
+// ExpandFailGlob makes ExpandArgs fail with ErrNoMatch when a pattern matches
+// no file, like bash's failglob option.
+func ExpandFailGlob() ExpandOption {
+	return func(cfg *expandConfig) {
+		cfg.failGlob = true
+	}
+}
+
+// ExpandNoGlob disables pathname expansion altogether, like sh's noglob
+// option.
+func ExpandNoGlob() ExpandOption {
+	return func(cfg *expandConfig) {
+		cfg.noGlob = true
+	}
+}
+
+// ExpandArgs expands argv the way a POSIX shell would expand the words of a
+// command line, without invoking a shell.
+//
+// Each argument first undergoes variable substitution with Substitute, unless
+// vars is nil. Arguments containing unescaped "*", "?" or "[" are then
+// treated as patterns, and replaced with the sorted list of paths of fsys
+// they match. Patterns follow the semantics of Fnmatch with FnmPathname and
+// FnmPeriod: wildcards do not match "/", nor a leading "." in a path
+// component. Since fsys has no notion of absolute paths, absolute patterns
+// never match.
+//
+// By default, a pattern that matches nothing is kept as is. This can be
+// changed with ExpandNullGlob and ExpandFailGlob.
+//
+// As in a shell, backslashes escape the character that follows them, and are
+// removed from the arguments that are not replaced by matches.
+func ExpandArgs(argv []string, vars VariableMap, fsys fs.FS, opts ...ExpandOption) ([]string, error) {
+	var cfg expandConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var out []string
+	for _, arg := range argv {
+		if vars != nil {
+			var err error
+			if arg, err = Substitute(arg, vars); err != nil {
+				return nil, err
+			}
+		}
+		if cfg.noGlob || !hasFnmatchMeta(arg) {
+			out = append(out, unescapeGlob(arg))
+			continue
+		}
+
+		matches, err := expandPattern(fsys, arg)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case len(matches) > 0:
+			out = append(out, matches...)
+		case cfg.failGlob:
+			return nil, fmt.Errorf("%w: %q", ErrNoMatch, arg)
+		case !cfg.nullGlob:
+			out = append(out, unescapeGlob(arg))
+		}
+	}
+	return out, nil
+}
+
+// hasFnmatchMeta returns true if pattern contains unescaped wildcards.
+func hasFnmatchMeta(pattern string) bool {
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '*', '?', '[':
+			return true
+		}
+	}
+	return false
+}
+
+// unescapeGlob removes the backslashes escaping characters in pattern.
+func unescapeGlob(pattern string) string {
+	if strings.IndexByte(pattern, '\\') == -1 {
+		return pattern
+	}
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] == '\\' && i+1 < len(pattern) {
+			i++
+		}
+		b.WriteByte(pattern[i])
+	}
+	return b.String()
+}
+
+// expandCandidate is a path being expanded. path is the path as derived from
+// the pattern, while fsPath is its equivalent in the filesystem. exists
+// records whether the path is known to exist.
+type expandCandidate struct {
+	path, fsPath string
+	exists       bool
+}
+
+func (c expandCandidate) join(name string, exists bool) expandCandidate {
+	switch {
+	case c.path == "":
+		c.path = name
+	default:
+		c.path += "/" + name
+	}
+	switch {
+	case name == "" || name == ".":
+	case c.fsPath == ".":
+		c.fsPath = name
+	default:
+		c.fsPath += "/" + name
+	}
+	c.exists = exists
+	return c
+}
+
+// expandPattern returns the sorted list of paths of fsys matching pattern.
+// Like glob(3), it matches pattern one path component at a time, only reading
+// the directories that components containing wildcards apply to.
+func expandPattern(fsys fs.FS, pattern string) ([]string, error) {
+	if strings.HasPrefix(pattern, "/") {
+		return nil, nil
+	}
+
+	candidates := []expandCandidate{{fsPath: ".", exists: true}}
+	components := strings.Split(pattern, "/")
+	for i, component := range components {
+		if component == ".." {
+			// fs.FS paths cannot escape their root.
+			return nil, nil
+		}
+
+		var next []expandCandidate
+		switch {
+		case i == len(components)-1 && component == "":
+			// A trailing slash only matches directories.
+			for _, c := range candidates {
+				if info, err := fs.Stat(fsys, c.fsPath); err == nil && info.IsDir() {
+					c.path += "/"
+					next = append(next, c)
+				}
+			}
+		case !hasFnmatchMeta(component):
+			for _, c := range candidates {
+				next = append(next, c.join(unescapeGlob(component), false))
+			}
+		default:
+			m, err := compileFnmatch(component, FnmPeriod)
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range candidates {
+				// Like shells, silently skip directories that cannot be read.
+				entries, _ := fs.ReadDir(fsys, c.fsPath)
+				for _, entry := range entries {
+					if m.match(entry.Name()) {
+						next = append(next, c.join(entry.Name(), true))
+					}
+				}
+			}
+		}
+		candidates = next
+	}
+
+	var matches []string
+	for _, c := range candidates {
+		if !c.exists {
+			if _, err := fs.Stat(fsys, c.fsPath); err != nil {
+				continue
+			}
+		}
+		matches = append(matches, c.path)
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
@@ -0,0 +1,74 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestExpandArgs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.go":          {},
+		"util.go":          {},
+		"README.md":        {},
+		".hidden.go":       {},
+		"cmd/tool/main.go": {},
+		"cmd/srv/main.go":  {},
+		"cmd/srv/.env":     {},
+		"lit*eral":         {},
+	}
+	vars := SimpleVariableMap{"ext": "go", "cmd": "tool"}
+
+	tcases := []struct {
+		In       string
+		Expected []string
+		Opts     []ExpandOption
+	}{
+		{"ls -l", []string{"ls", "-l"}, nil},
+		{"ls *.go", []string{"ls", "main.go", "util.go"}, nil},
+		{"ls *.${ext}", []string{"ls", "main.go", "util.go"}, nil},
+		{"ls .*.go", []string{"ls", ".hidden.go"}, nil},
+		{"ls cmd/*/main.go", []string{"ls", "cmd/srv/main.go", "cmd/tool/main.go"}, nil},
+		{"ls cmd/${cmd}/*", []string{"ls", "cmd/tool/main.go"}, nil},
+		{"ls cmd/*/", []string{"ls", "cmd/srv/", "cmd/tool/"}, nil},
+		{"ls ./cmd/s?v/*", []string{"ls", "./cmd/srv/main.go"}, nil},
+		{"ls cmd/*/missing.go", []string{"ls", "cmd/*/missing.go"}, nil},
+		{"ls [MR]*", []string{"ls", "README.md"}, nil},
+		{`ls lit\*eral`, []string{"ls", "lit*eral"}, nil},
+		{`ls lit\*e*`, []string{"ls", "lit*eral"}, nil},
+		{"ls ../*", []string{"ls", "../*"}, nil},
+		{"ls /*", []string{"ls", "/*"}, nil},
+		{"ls *.c", []string{"ls", "*.c"}, nil},
+		{"ls *.c", []string{"ls"}, []ExpandOption{ExpandNullGlob()}},
+		{"ls *.go", []string{"ls", "*.go"}, []ExpandOption{ExpandNoGlob()}},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.In, func(t *testing.T) {
+			actual, err := ExpandArgs(strings.Fields(tc.In), vars, fsys, tc.Opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+		})
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := ExpandArgs([]string{"ls", "*.c"}, nil, fsys, ExpandFailGlob())
+		if !errors.Is(err, ErrNoMatch) {
+			t.Fatalf("expected %v, got %v", ErrNoMatch, err)
+		}
+		if _, err := ExpandArgs([]string{"${undefined}"}, vars, fsys); err == nil {
+			t.Fatalf("expected substitution error")
+		}
+	})
+}
@@ -0,0 +1,199 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"fmt"
+	"strings"
+)
+
+// An Explanation describes why a string matches a glob pattern or not, as
+// returned by Glob.Explain. Its String method phrases it for humans, as in:
+//
+//	component 3 "main.c" does not match "*.go"
+type Explanation struct {
+	// Pattern is the pattern of the glob, and Input the string matched.
+	Pattern, Input string
+
+	// Match reports whether Input matches Pattern.
+	Match bool
+
+	// Component is the number, counting from 1, of the path component of
+	// Input where matching failed, or 0 if Input matches, or if it ended
+	// before the pattern did. If Input does not match but no component can
+	// be blamed, it is the last one.
+	Component int
+
+	// Text is the component of Input where matching failed.
+	Text string
+
+	// Segment is the component of Pattern that Text failed to match, if it
+	// can be told. Components matching any number of components, like
+	// "**", make the following ones impossible to pair with the ones of
+	// Input.
+	Segment string
+
+	// Missing lists the components of Pattern left unmatched when Input
+	// ended before the pattern did, if they can be told.
+	Missing []string
+
+	// Extra is set if Text is a component of Input beyond the last one of
+	// Pattern.
+	Extra bool
+}
+
+// Explain returns why data matches the glob pattern or not, which helps
+// debugging patterns, like ignore rules that do not apply as expected.
+//
+// Data is matched component by component, as Match would, until no string
+// starting like data can match. The explanation reports the component of
+// data where this happened, and, if it can be told, the component of the
+// pattern it failed to match. Options altering data, like CleanPath and
+// MatchBase, apply before the components are counted.
+func (g *Glob) Explain(data string) Explanation {
+	e := Explanation{Pattern: g.pattern, Input: data, Match: g.Match(data)}
+	if e.Match {
+		return e
+	}
+
+	in := g.opts.input()
+	prepared := in.prepare(data, '/')
+	components := strings.Split(prepared, "/")
+	segments := g.segments()
+
+	// Find where the last thread of the program died.
+	m := g.prog.machine(false)
+	dead := -1
+	for i := 0; i < len(prepared); {
+		r, n := decodeRune(prepared[i:], g.prog.utf8)
+		if !m.step(r) {
+			dead = i
+			break
+		}
+		i += n
+	}
+	m.release()
+
+	c := len(components) - 1
+	if dead != -1 {
+		c = strings.Count(prepared[:dead], "/")
+		if prepared[dead] == '/' && c == len(segments)-1 && g.aligned(segments, c) && g.matchesSegment(segments[c], components[c]) {
+			// Data has more components than the pattern.
+			e.Component, e.Text, e.Extra = c+2, in.swap(components[c+1]), true
+			return e
+		}
+	} else if g.aligned(segments, c) && c < len(segments) && g.matchesSegment(segments[c], components[c]) {
+		// All of data was consumed, but the pattern expects more.
+		for _, seg := range segments[c+1:] {
+			e.Missing = append(e.Missing, in.swap(seg))
+		}
+		return e
+	}
+
+	e.Component = c + 1
+	e.Text = in.swap(components[c])
+	if c < len(segments) && g.aligned(segments, c) {
+		e.Segment = in.swap(segments[c])
+	}
+	return e
+}
+
+// matchesSegment returns whether the component of a string matches the
+// segment of the pattern of g.
+func (g *Glob) matchesSegment(segment, component string) bool {
+	sg, err := CompileGlobOptions(segment, GlobOptions{
+		CaseInsensitive: g.opts.CaseInsensitive,
+		Period:          g.opts.Period,
+		SkipHidden:      g.opts.SkipHidden,
+	})
+	return err == nil && sg.Match(component)
+}
+
+func (e Explanation) String() string {
+	switch {
+	case e.Match:
+		return fmt.Sprintf("%q matches %q", e.Input, e.Pattern)
+	case e.Component == 0 && len(e.Missing) != 0:
+		return fmt.Sprintf("%q ends before %q does: %q is missing", e.Input, e.Pattern, strings.Join(e.Missing, "/"))
+	case e.Component == 0:
+		return fmt.Sprintf("%q ends before %q does", e.Input, e.Pattern)
+	case e.Extra:
+		return fmt.Sprintf("component %d %q is beyond the end of %q", e.Component, e.Text, e.Pattern)
+	case e.Segment != "":
+		return fmt.Sprintf("component %d %q does not match %q", e.Component, e.Text, e.Segment)
+	}
+	return fmt.Sprintf("component %d %q does not match %q", e.Component, e.Text, e.Pattern)
+}
+
+// segments returns the components of the pattern, as matched against the
+// components of strings: the custom separator of the glob, if any, is
+// swapped with "/". It returns nil if they cannot be paired with the
+// components of strings.
+func (g *Glob) segments() []string {
+	if g.opts.CrossSeparators || g.opts.Unanchored {
+		return nil
+	}
+	pattern := g.opts.input().swap(g.pattern)
+	if g.negated {
+		pattern = pattern[len("!"):]
+	}
+	if g.opts.AnchorAnywhere {
+		if !strings.Contains(strings.TrimSuffix(pattern, "/"), "/") {
+			// The pattern matches at any depth.
+			return nil
+		}
+		pattern = strings.TrimPrefix(pattern, "/")
+	}
+	return splitPattern(pattern)
+}
+
+// splitPattern splits pattern on the slashes separating its components,
+// leaving alone the escaped ones, and the ones in bracket expressions and
+// brace groups.
+func splitPattern(pattern string) []string {
+	var segments []string
+	start, depth := 0, 0
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '[':
+			if end := classEnd(pattern, i); end != -1 {
+				i = end - 1
+			}
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '/':
+			if depth == 0 {
+				segments = append(segments, pattern[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(segments, pattern[start:])
+}
+
+// aligned returns whether the component of index n of strings is paired
+// with the segment of the same index, that is, whether the segments before
+// it each match exactly one component. Segments like "**" may match no
+// component, or several, and break the pairing after them, as do all
+// wildcards with CrossSeparators.
+func (g *Glob) aligned(segments []string, n int) bool {
+	if segments == nil {
+		return false
+	}
+	for _, seg := range segments[:min(n, len(segments))] {
+		if strings.Contains(seg, "**") || strings.Contains(seg, "/") {
+			return false
+		}
+		if g.opts.CrossSeparators && strings.ContainsAny(seg, "*?[") {
+			return false
+		}
+	}
+	return true
+}
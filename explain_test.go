@@ -0,0 +1,50 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"testing"
+)
+
+func TestGlobExplain(t *testing.T) {
+	tcases := []struct {
+		Pattern  string
+		Options  GlobOptions
+		Input    string
+		Expected string
+	}{
+		{"src/*.go", GlobOptions{}, "src/main.go", `"src/main.go" matches "src/*.go"`},
+		{"src/*.go", GlobOptions{}, "src/main.c", `component 2 "main.c" does not match "*.go"`},
+		{"src/*.go", GlobOptions{}, "lib/main.go", `component 1 "lib" does not match "src"`},
+		{"src/*.go", GlobOptions{}, "src/main.g", `component 2 "main.g" does not match "*.go"`},
+		{"src/*.go", GlobOptions{}, "src/a/b.go", `component 2 "a" does not match "*.go"`},
+		{"src/*.go", GlobOptions{}, "src/main.go/x", `component 3 "x" is beyond the end of "src/*.go"`},
+		{"src/*/gen/*.go", GlobOptions{}, "src/a", `"src/a" ends before "src/*/gen/*.go" does: "gen/*.go" is missing`},
+		{"a/b/c/*.go", GlobOptions{}, "a/b/foo/x.go", `component 3 "foo" does not match "c"`},
+		{"x/*/y", GlobOptions{}, "x/a/y/z", `component 4 "z" is beyond the end of "x/*/y"`},
+		{"x/*/y", GlobOptions{}, "x/a/z", `component 3 "z" does not match "y"`},
+		{"x/*/y", GlobOptions{CrossSeparators: true}, "x/a/b/z", `component 4 "z" does not match "x/*/y"`},
+		{"src/**/*.go", GlobOptions{}, "src/a/b/c.md", `component 4 "c.md" does not match "src/**/*.go"`},
+		{"src/**/*.go", GlobOptions{}, "lib/a.go", `component 1 "lib" does not match "src"`},
+		{"{a,b/c}/x", GlobOptions{}, "b/d/x", `component 2 "d" does not match "{a,b/c}/x"`},
+		{"[/]x/y", GlobOptions{}, "/x/z", `component 3 "z" does not match "[/]x/y"`},
+		{"a.b*.c", GlobOptions{Separator: '.'}, "a.bx.d", `component 3 "d" does not match "c"`},
+		{"SRC/*", GlobOptions{CaseInsensitive: true}, "lib/x", `component 1 "lib" does not match "SRC"`},
+		{"*.go", GlobOptions{MatchBase: true}, "src/main.c", `component 1 "main.c" does not match "*.go"`},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern+" "+tc.Input, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, tc.Options)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := g.Explain(tc.Input).String(); actual != tc.Expected {
+				t.Fatalf("expected %s, got %s", tc.Expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	ErrMalformedFilterRule = errors.New("malformed filter rule")
+)
+
+type filterRule struct {
+	glob    *Glob
+	include bool
+	dirOnly bool
+}
+
+// Filter represents an ordered list of include and exclude rules, evaluated
+// with the semantics of rsync(1) filter rules: the first rule matching a path
+// decides whether it is included, and paths matching no rule are included.
+//
+// This differs from IgnoreSet, where the last matching rule wins.
+//
+// Each rule is either "+ pattern", which includes the matching paths, or
+// "- pattern", which excludes them. Patterns follow the rsync syntax:
+//
+//  - A pattern starting with "/" is anchored to the root of the tree, while
+//    other patterns match the final components of a path, at any depth.
+//  - A pattern ending with "/" only matches directories.
+//  - A pattern ending with "/***" matches a directory and all its contents.
+//  - "*", "?" and bracket expressions do not match "/", while "**" does.
+//    Curly braces match themselves.
+type Filter struct {
+	rules []filterRule
+}
+
+// CompileFilter compiles the specified ordered list of rules into a Filter.
+func CompileFilter(rules []string) (*Filter, error) {
+	f := &Filter{rules: make([]filterRule, 0, len(rules))}
+	for i, rule := range rules {
+		r, err := compileFilterRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		f.rules = append(f.rules, r)
+	}
+	return f, nil
+}
+
+// MustCompileFilter is like CompileFilter, but panics if the function returned an error.
+func MustCompileFilter(rules []string) *Filter {
+	f, err := CompileFilter(rules)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// ParseFilterFile parses rules from r, one per line, and compiles them into a
+// Filter. Blank lines and lines starting with "#" or ";" are ignored.
+//
+// The rules are evaluated as described in Filter, first match winning,
+// which makes the files it reads different from those of LoadPatterns, where
+// the last matching pattern wins and exclusions start with "!".
+func ParseFilterFile(r io.Reader) (*Filter, error) {
+	var rules []string
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		rule := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.TrimSpace(rule) == "" || strings.HasPrefix(rule, "#") || strings.HasPrefix(rule, ";") {
+			continue
+		}
+		if _, err := compileFilterRule(rule); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return CompileFilter(rules)
+}
+
+func compileFilterRule(rule string) (filterRule, error) {
+	var r filterRule
+	switch {
+	case strings.HasPrefix(rule, "+ "):
+		r.include = true
+	case strings.HasPrefix(rule, "- "):
+	default:
+		return r, fmt.Errorf("%w: %q", ErrMalformedFilterRule, rule)
+	}
+	pattern := rule[len("+ "):]
+
+	var b strings.Builder
+	var suffix string
+	switch {
+	case strings.HasSuffix(pattern, "/***"):
+		pattern, suffix = strings.TrimSuffix(pattern, "/***"), "{,/**}"
+	case strings.HasSuffix(pattern, "/"):
+		pattern, r.dirOnly = strings.TrimSuffix(pattern, "/"), true
+	}
+	if strings.HasPrefix(pattern, "/") {
+		pattern = pattern[1:]
+	} else {
+		b.WriteString("**/")
+	}
+	if pattern == "" {
+		return r, fmt.Errorf("%w: %q", ErrMalformedFilterRule, rule)
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '\\':
+			b.WriteByte(c)
+			if i+1 < len(pattern) {
+				i++
+				b.WriteByte(pattern[i])
+			}
+		case '{', '}', ',', '!':
+			b.WriteRune('\\')
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteString(suffix)
+
+	var err error
+	r.glob, err = CompileGlob(b.String())
+	return r, err
+}
+
+// Match returns whether path is included by the filter. isDir reports
+// whether path is a directory; a path ending with "/" is always treated as
+// one.
+//
+// As with rsync, the contents of an excluded directory are excluded as well,
+// whatever the rules matching them: path is only included if all its parent
+// directories are. Walkers can thus skip the directories Match excludes. A
+// rule like "+ */" is commonly used to include all directories, so that
+// later rules can select the files within them.
+func (f *Filter) Match(path string, isDir bool) bool {
+	if strings.HasSuffix(path, "/") {
+		path, isDir = strings.TrimSuffix(path, "/"), true
+	}
+	for i := 1; i < len(path); i++ {
+		if path[i] == '/' && !f.include(path[:i], true) {
+			return false
+		}
+	}
+	return f.include(path, isDir)
+}
+
+// include evaluates the rules against path alone.
+func (f *Filter) include(path string, isDir bool) bool {
+	for _, rule := range f.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if rule.glob.Match(path) {
+			return rule.include
+		}
+	}
+	return true
+}
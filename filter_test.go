@@ -0,0 +1,99 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	f := MustCompileFilter([]string{
+		"- *.tmp",
+		"+ /src/***",
+		"- /docs/internal/",
+		"+ */",
+		"+ *.md",
+		"- *",
+	})
+
+	tcases := []struct {
+		Path     string
+		IsDir    bool
+		Included bool
+	}{
+		{"README.md", false, true},
+		{"main.go", false, false},
+		{"src", true, true},
+		{"src/main.go", false, true},
+		{"src/a/b/c.go", false, true},
+		{"src/a/x.tmp", false, false},
+		{"docs", true, true},
+		{"docs/index.md", false, true},
+		{"docs/internal", true, false},
+		{"docs/internal/", false, false},
+		{"docs/internal/notes.md", false, false},
+		{"docs/internal.md", false, true},
+		{"x/y/z.md", false, true},
+		{"x/y/z.go", false, false},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Path, func(t *testing.T) {
+			if included := f.Match(tc.Path, tc.IsDir); included != tc.Included {
+				t.Fatalf("expected %v, got %v", tc.Included, included)
+			}
+		})
+	}
+
+	t.Run("FirstMatchWins", func(t *testing.T) {
+		f := MustCompileFilter([]string{"+ keep.log", "- *.log"})
+		if !f.Match("a/keep.log", false) || f.Match("a/other.log", false) {
+			t.Fatalf("expected the first matching rule to win")
+		}
+		f = MustCompileFilter([]string{"- *.log", "+ keep.log"})
+		if f.Match("a/keep.log", false) {
+			t.Fatalf("expected the first matching rule to win")
+		}
+	})
+
+	t.Run("Literal", func(t *testing.T) {
+		f := MustCompileFilter([]string{"- {a,b}", "- !x"})
+		if f.Match("{a,b}", false) || f.Match("!x", false) || !f.Match("a", false) {
+			t.Fatalf("expected braces and exclamation marks to match themselves")
+		}
+	})
+}
+
+func TestFilterErrors(t *testing.T) {
+	for _, rule := range []string{"*.go", "+", "+ ", "- /", "x *.go"} {
+		t.Run(rule, func(t *testing.T) {
+			if _, err := CompileFilter([]string{rule}); !errors.Is(err, ErrMalformedFilterRule) {
+				t.Fatalf("expected %v, got %v", ErrMalformedFilterRule, err)
+			}
+		})
+	}
+	_, err := CompileFilter([]string{"+ *.go", "- [a"})
+	if !errors.Is(err, ErrUnterminatedClass) || !strings.HasPrefix(err.Error(), "rule 1:") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestParseFilterFile(t *testing.T) {
+	f, err := ParseFilterFile(strings.NewReader("# comment\n; comment\n\n+ *.go\r\n- *\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !f.Match("main.go", false) || f.Match("main.c", false) {
+		t.Fatalf("unexpected filter matches")
+	}
+
+	_, err = ParseFilterFile(strings.NewReader("+ *.go\n\n*.c\n"))
+	if !errors.Is(err, ErrMalformedFilterRule) || !strings.HasPrefix(err.Error(), "line 3:") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
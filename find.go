@@ -0,0 +1,239 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"strings"
+)
+
+// maxFindAlternatives bounds the number of alternatives brace groups expand
+// to in find expressions.
+const maxFindAlternatives = 256
+
+// FindArgs returns the arguments of a find(1) command printing the paths
+// under root that match the glob pattern, as in:
+//
+//	find src \( -path 'src/*.go' ! -path 'src/*/*' \)
+//
+// The arguments are root, followed by an expression made of -path, -name
+// and -type primaries, which are supported by all implementations of find.
+// As with Walk, paths are matched relative to root, and root itself never
+// matches. This allows offloading walks to find, for instance on remote
+// hosts.
+//
+// Unlike the wildcards of globs, the wildcards of -path match slashes: the
+// expression bounds the number of slashes in paths, or matches their last
+// component with -name, to account for it. Patterns for which this is not
+// possible, like "**/a*/b", make FindArgs return an error wrapping
+// ErrUntranslatable. So do negated patterns, and the Period, Unanchored,
+// Separator and SkipHidden options, and invalid UTF-8 matched as raw bytes.
+// Case-insensitive patterns are translated with -ipath and -iname.
+func (g *Glob) FindArgs(root string) ([]string, error) {
+	switch {
+	case g.negated:
+		return nil, g.untranslatable("negated pattern")
+	case g.opts.Period, g.opts.Unanchored, g.opts.Separator != 0, g.opts.SkipHidden:
+		return nil, g.untranslatable("unsupported option")
+	case g.rawBytes():
+		return nil, g.untranslatable("invalid UTF-8 matched as raw bytes")
+	}
+
+	seqs, ok := expandAlts([][]node{nil}, g.nodes)
+	if !ok {
+		return nil, g.untranslatable("too many alternatives")
+	}
+	prefix := QuoteGlobMeta(root)
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	var exprs [][]string
+	for _, seq := range seqs {
+		expr, err := g.findExpr(prefix, seq)
+		if err != nil {
+			return nil, err
+		}
+		if expr != nil {
+			exprs = append(exprs, expr)
+		}
+	}
+	if len(exprs) == 0 {
+		// Only root matches, and it is not part of the results.
+		return []string{root, "!", "-path", "*"}, nil
+	}
+
+	args := []string{root}
+	if len(exprs) == 1 {
+		return append(args, exprs[0]...), nil
+	}
+	args = append(args, "(")
+	for i, expr := range exprs {
+		if i > 0 {
+			args = append(args, "-o")
+		}
+		args = append(args, expr...)
+	}
+	return append(args, ")"), nil
+}
+
+// FindCommand returns the find(1) command line running FindArgs(root),
+// quoted for the shell with Quote.
+func (g *Glob) FindCommand(root string) (string, error) {
+	args, err := g.FindArgs(root)
+	if err != nil {
+		return "", err
+	}
+	return Quote(append([]string{"find"}, args...)), nil
+}
+
+// expandAlts returns the sequences of nodes without alternatives matching
+// nodes, each prefixed with one of seqs. It returns false if there are more
+// than maxFindAlternatives of them.
+func expandAlts(seqs [][]node, nodes []node) ([][]node, bool) {
+	for _, n := range nodes {
+		if n.op != nodeAlt {
+			for i := range seqs {
+				seqs[i] = append(seqs[i][:len(seqs[i]):len(seqs[i])], n)
+			}
+			continue
+		}
+		var expanded [][]node
+		for _, alt := range n.alts {
+			alts, ok := expandAlts(append([][]node(nil), seqs...), alt)
+			if !ok {
+				return nil, false
+			}
+			expanded = append(expanded, alts...)
+		}
+		if len(expanded) > maxFindAlternatives {
+			return nil, false
+		}
+		seqs = expanded
+	}
+	return seqs, true
+}
+
+// findExpr returns the find primaries matching the paths, starting with
+// prefix, matched by seq, which contains no alternatives. It returns nil if
+// seq only matches the root.
+func (g *Glob) findExpr(prefix string, seq []node) ([]string, error) {
+	pathPrimary, namePrimary := "-path", "-name"
+	if g.opts.CaseInsensitive {
+		pathPrimary, namePrimary = "-ipath", "-iname"
+	}
+
+	dir := false
+	if n := len(seq); n > 0 && seq[n-1].op == nodeRune && seq[n-1].r == '/' {
+		// Paths printed by find have no trailing slash.
+		seq = seq[:n-1]
+		dir = !g.opts.TrailingSlash
+	}
+	if len(seq) == 0 {
+		return nil, nil
+	}
+
+	// Wildcards not matching slashes are only exact if the number of
+	// slashes in paths is known, or if they are in the last component,
+	// matched with -name.
+	var b strings.Builder
+	slashes, lastSlash := 0, -1
+	anySlash, bounded, slashClass := false, false, false
+	for i, n := range seq {
+		switch {
+		case n.op == nodeRune && n.r == '/':
+			slashes++
+			lastSlash = i
+		case n.op == nodeStar && n.class == anyRune:
+			anySlash = true
+		case n.op == nodeStar && n.class == anyButSlash:
+			bounded = true
+		case n.op == nodeStar:
+			return nil, g.untranslatable("star not expressible with find")
+		case n.op == nodeClass && n.class.matches('/'):
+			slashClass = true
+		}
+		if err := g.writeFindNode(&b, n); err != nil {
+			return nil, err
+		}
+	}
+	expr := []string{pathPrimary, prefix + b.String()}
+
+	if bounded && slashClass {
+		return nil, g.untranslatable("star not expressible with find")
+	}
+	if g.opts.MatchBase {
+		b.Reset()
+		for _, n := range seq {
+			g.writeFindNode(&b, n)
+		}
+		expr = []string{namePrimary, b.String()}
+	} else if bounded && !anySlash {
+		// The number of slashes is bounded by the ones of the pattern.
+		expr = append(expr, "!", "-path", prefix+strings.Repeat("*/", slashes+1)+"*")
+	} else if bounded {
+		last := seq[lastSlash+1:]
+		for _, n := range seq[:lastSlash+1] {
+			if n.op == nodeStar && n.class == anyButSlash {
+				return nil, g.untranslatable("star not expressible with find")
+			}
+		}
+		b.Reset()
+		for _, n := range last {
+			if n.op == nodeStar && n.class == anyRune {
+				return nil, g.untranslatable("star not expressible with find")
+			}
+			g.writeFindNode(&b, n)
+		}
+		expr = append(expr, namePrimary, b.String())
+	}
+	if dir {
+		expr = append(expr, "-type", "d")
+	}
+	return expr, nil
+}
+
+// writeFindNode writes the fnmatch(3) pattern matching n, which is not an
+// alternative, to b. Stars are written as "*", which matches slashes.
+func (g *Glob) writeFindNode(b *strings.Builder, n node) error {
+	switch n.op {
+	case nodeRune:
+		if strings.ContainsRune(`*?[\`, n.r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(n.r)
+	case nodeStar:
+		b.WriteRune('*')
+	case nodeClass:
+		if n.class == anyRune {
+			b.WriteRune('?')
+			return nil
+		}
+		if len(n.class.ranges) == 0 {
+			return g.untranslatable("empty class")
+		}
+		b.WriteRune('[')
+		if n.class.negated {
+			b.WriteRune('!')
+		}
+		for _, rg := range n.class.ranges {
+			writeFindClassRune(b, rg.lo)
+			if rg.hi != rg.lo {
+				b.WriteRune('-')
+				writeFindClassRune(b, rg.hi)
+			}
+		}
+		b.WriteRune(']')
+	}
+	return nil
+}
+
+func writeFindClassRune(b *strings.Builder, r rune) {
+	switch r {
+	case '\\', '-', '!', '^', '[', ']':
+		b.WriteRune('\\')
+	}
+	b.WriteRune(r)
+}
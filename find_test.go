@@ -0,0 +1,127 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestGlobFindArgs(t *testing.T) {
+	tcases := []struct {
+		Pattern  string
+		Opts     GlobOptions
+		Root     string
+		Expected []string
+	}{
+		{"src/main.go", GlobOptions{}, ".", []string{".", "-path", "./src/main.go"}},
+		{"src/**", GlobOptions{}, ".", []string{".", "-path", "./src/*"}},
+		{"src/*.go", GlobOptions{}, "dir", []string{"dir", "-path", "dir/src/*.go", "!", "-path", "dir/*/*/*"}},
+		{"**/*.go", GlobOptions{}, ".", []string{".", "(",
+			"-path", "./*.go", "!", "-path", "./*/*", "-o",
+			"-path", "./*/*.go", "-name", "*.go", ")"}},
+		{"*/", GlobOptions{}, "/", []string{"/", "-path", "/*", "!", "-path", "/*/*", "-type", "d"}},
+		{"[!a]?", GlobOptions{}, ".", []string{".", "-path", "./[!a][!/]"}},
+		{"{a,b*}", GlobOptions{}, ".", []string{".", "(",
+			"-path", "./a", "-o",
+			"-path", "./b*", "!", "-path", "./*/*", ")"}},
+		{`a\*b`, GlobOptions{}, "x*", []string{"x*", "-path", `x\*/a\*b`}},
+		{"*.GO", GlobOptions{CaseInsensitive: true}, ".", []string{".", "-ipath", "./*.GO", "!", "-path", "./*/*"}},
+		{"*.go", GlobOptions{MatchBase: true}, ".", []string{".", "-name", "*.go"}},
+		{"", GlobOptions{}, ".", []string{".", "!", "-path", "*"}},
+		{"**/a*/b", GlobOptions{}, ".", nil},
+		{"*[!a]", GlobOptions{}, ".", nil},
+		{"!*.go", GlobOptions{}, ".", nil},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, tc.Opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual, err := g.FindArgs(tc.Root)
+			if tc.Expected == nil {
+				if !errors.Is(err, ErrUntranslatable) {
+					t.Fatalf("expected %v, got %q, %v", ErrUntranslatable, actual, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+		})
+	}
+
+	cmd, err := MustCompileGlob("a b/*.go").FindCommand(".")
+	if expected := `find . -path './a b/*.go' ! -path './*/*/*'`; err != nil || cmd != expected {
+		t.Fatalf("expected %q, got %q, %v", expected, cmd, err)
+	}
+}
+
+func TestGlobFindArgsRun(t *testing.T) {
+	find, err := exec.LookPath("find")
+	if err != nil {
+		t.Skip("find not found")
+	}
+	dir := t.TempDir()
+	for _, name := range []string{"a.go", "b.md", "src/c.go", "src/lib/d.go", "src/lib/e.txt"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, pattern := range []string{"**/*.go", "*", "src/*", "src/**", "*/", "{a,src/*}.go", "src/*/*.txt"} {
+		t.Run(pattern, func(t *testing.T) {
+			g := MustCompileGlob(pattern)
+			args, err := g.FindArgs(".")
+			if err != nil {
+				t.Fatal(err)
+			}
+			cmd := exec.Command(find, args...)
+			cmd.Dir = dir
+			out, err := cmd.Output()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var actual []string
+			for _, line := range strings.Fields(string(out)) {
+				actual = append(actual, strings.TrimPrefix(line, "./"))
+			}
+			sort.Strings(actual)
+
+			var expected []string
+			walked, err := g.Walk(os.DirFS(dir))
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Walk also returns the directories that only match with a
+			// trailing slash, like "src" for "src/*", which find does not.
+			for _, path := range walked {
+				if info, err := os.Stat(filepath.Join(dir, path)); err == nil && (g.Match(path) || info.IsDir() && g.DirOnly()) {
+					expected = append(expected, path)
+				}
+			}
+			sort.Strings(expected)
+			if !reflect.DeepEqual(actual, expected) {
+				t.Fatalf("%q: expected %q, got %q", args, expected, actual)
+			}
+		})
+	}
+}
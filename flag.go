@@ -0,0 +1,82 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"strings"
+)
+
+// GlobFlag is a flag.Value holding a glob pattern, which is compiled, and
+// therefore validated, when the flag is parsed.
+//
+//	var include shutil.GlobFlag
+//	flag.Var(&include, "include", "only process files matching `pattern`")
+type GlobFlag struct {
+
+	// Glob is the compiled pattern, or nil if the flag was not set.
+	Glob *Glob
+}
+
+func (f *GlobFlag) String() string {
+	if f == nil || f.Glob == nil {
+		return ""
+	}
+	return f.Glob.String()
+}
+
+func (f *GlobFlag) Set(pattern string) error {
+	g, err := CompileGlob(pattern)
+	if err != nil {
+		return err
+	}
+	f.Glob = g
+	return nil
+}
+
+// Match returns whether path matches the glob of the flag. An unset flag
+// matches everything.
+func (f *GlobFlag) Match(path string) bool {
+	return f.Glob == nil || f.Glob.Match(path)
+}
+
+// GlobListFlag is a flag.Value accumulating the glob patterns of a flag that
+// can be repeated. Each pattern is compiled, and therefore validated, when
+// the flag is parsed.
+//
+//	var excludes shutil.GlobListFlag
+//	flag.Var(&excludes, "exclude", "skip files matching `pattern` (can be repeated)")
+type GlobListFlag []*Glob
+
+func (f *GlobListFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	patterns := make([]string, len(*f))
+	for i, g := range *f {
+		patterns[i] = g.String()
+	}
+	return strings.Join(patterns, ",")
+}
+
+func (f *GlobListFlag) Set(pattern string) error {
+	g, err := CompileGlob(pattern)
+	if err != nil {
+		return err
+	}
+	*f = append(*f, g)
+	return nil
+}
+
+// Match returns whether path matches at least one glob of the flag. An unset
+// flag matches nothing.
+func (f GlobListFlag) Match(path string) bool {
+	for _, g := range f {
+		if g.Match(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestGlobFlags(t *testing.T) {
+	newFlagSet := func() (*flag.FlagSet, *GlobFlag, *GlobListFlag) {
+		var (
+			include  GlobFlag
+			excludes GlobListFlag
+		)
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.Var(&include, "include", "")
+		fs.Var(&excludes, "exclude", "")
+		return fs, &include, &excludes
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		fs, include, excludes := newFlagSet()
+		err := fs.Parse([]string{"--include", "src/**", "--exclude", "*_test.go", "--exclude", "**/testdata/**"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if include.String() != "src/**" || excludes.String() != "*_test.go,**/testdata/**" {
+			t.Fatalf("unexpected flag values %q and %q", include, excludes)
+		}
+		if !include.Match("src/main.go") || include.Match("main.go") {
+			t.Fatalf("include flag does not match as expected")
+		}
+		if !excludes.Match("main_test.go") || !excludes.Match("src/testdata/x") || excludes.Match("main.go") {
+			t.Fatalf("exclude flag does not match as expected")
+		}
+	})
+
+	t.Run("Unset", func(t *testing.T) {
+		fs, include, excludes := newFlagSet()
+		if err := fs.Parse(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !include.Match("main.go") || excludes.Match("main.go") {
+			t.Fatalf("unset flags do not match as expected")
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		fs, _, _ := newFlagSet()
+		if err := fs.Parse([]string{"--exclude", "[a"}); err == nil {
+			t.Fatalf("expected error for invalid pattern")
+		}
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"strings"
+)
+
+// Flags altering the behaviour of Fnmatch. Their values are the same as
+// those of the corresponding FNM_* flags of glibc's fnmatch(3).
+const (
+	// FnmPathname makes slashes in name only match a literal slash in
+	// pattern, and never a "*", "?" or bracket expression.
+	FnmPathname = 1 << 0
+
+	// FnmNoEscape treats backslashes as ordinary characters rather than
+	// escape characters.
+	FnmNoEscape = 1 << 1
+
+	// FnmPeriod makes a leading period in name only match a literal period in
+	// pattern. A period is leading if it is the first character of name, or,
+	// if FnmPathname is set, if it immediately follows a slash.
+	FnmPeriod = 1 << 2
+
+	// FnmCaseFold matches pattern against name case-insensitively.
+	FnmCaseFold = 1 << 4
+)
+
+// Fnmatch returns whether name matches the shell wildcard pattern, following
+// the semantics of fnmatch(3) as altered by flags.
+//
+// Unlike Glob, Fnmatch only supports the syntax of fnmatch(3): brace
+// expansion, "**" and pattern negation are not supported, and the
+// corresponding characters match themselves. An unterminated bracket
+// expression is treated as a literal "[".
+func Fnmatch(pattern, name string, flags int) (bool, error) {
+	m, err := compileFnmatch(pattern, flags)
+	if err != nil {
+		return false, err
+	}
+	return m.match(name), nil
+}
+
+// fnmatcher is a pattern compiled with fnmatch(3) semantics.
+type fnmatcher struct {
+	pattern  string
+	flags    int
+	prog     *program
+	segments []int
+}
+
+func compileFnmatch(pattern string, flags int) (*fnmatcher, error) {
+	p := globParser{in: pattern, flags: flags, fnmatch: true}
+	nodes, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	prog := new(program)
+	prog.compile(nodes, instMode{fold: flags&FnmCaseFold != 0})
+	prog.emit(inst{op: instMatch})
+	return &fnmatcher{pattern: pattern, flags: flags, prog: prog, segments: p.segments}, nil
+}
+
+func (m *fnmatcher) match(name string) bool {
+	if !m.prog.matchString(name) {
+		return false
+	}
+	if m.flags&FnmPeriod == 0 {
+		return true
+	}
+
+	// A leading period must have been matched by a literal period at the start
+	// of the corresponding pattern component. Since wildcards never match
+	// slashes with FnmPathname, components of name and pattern line up.
+	components, starts := []string{name}, []int{0}
+	if m.flags&FnmPathname != 0 {
+		components, starts = strings.Split(name, "/"), m.segments
+	}
+	for i, component := range components {
+		if !strings.HasPrefix(component, ".") {
+			continue
+		}
+		rest := m.pattern[starts[i]:]
+		if !strings.HasPrefix(rest, ".") && (m.flags&FnmNoEscape != 0 || !strings.HasPrefix(rest, `\.`)) {
+			return false
+		}
+	}
+	return true
+}
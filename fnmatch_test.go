@@ -0,0 +1,74 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"testing"
+)
+
+func TestFnmatch(t *testing.T) {
+	tcases := []struct {
+		Pattern, Name string
+		Flags         int
+		Match         bool
+	}{
+		{"*", "dir/file", 0, true},
+		{"*", "dir/file", FnmPathname, false},
+		{"*/*", "dir/file", FnmPathname, true},
+		{"dir?file", "dir/file", 0, true},
+		{"dir?file", "dir/file", FnmPathname, false},
+		{"dir[/]file", "dir/file", 0, true},
+		{"dir[/]file", "dir/file", FnmPathname, false},
+		{"dir[!a]file", "dir/file", FnmPathname, false},
+		{"dir[^a]file", "dir/file", FnmPathname, false},
+		{"dir[^a]file", "dir-file", FnmPathname, true},
+		{"**", "dir/file", FnmPathname, false},
+
+		{`\*`, "*", 0, true},
+		{`\*`, "x", 0, false},
+		{`\*`, `\x`, FnmNoEscape, true},
+		{`[\]]`, "]", 0, true},
+		{`[\]]`, `\]`, FnmNoEscape, true},
+
+		{"*", ".hidden", 0, true},
+		{"*", ".hidden", FnmPeriod, false},
+		{"?hidden", ".hidden", FnmPeriod, false},
+		{"[.]hidden", ".hidden", FnmPeriod, false},
+		{"*.hidden", ".hidden", FnmPeriod, false},
+		{".*", ".hidden", FnmPeriod, true},
+		{`\.*`, ".hidden", FnmPeriod, true},
+		{"dir/*", "dir/.hidden", FnmPeriod, true},
+		{"dir/*", "dir/.hidden", FnmPeriod | FnmPathname, false},
+		{"dir/.*", "dir/.hidden", FnmPeriod | FnmPathname, true},
+		{"*/file", ".dir/file", FnmPeriod | FnmPathname, false},
+
+		{"FILE.*", "file.c", 0, false},
+		{"FILE.*", "file.c", FnmCaseFold, true},
+		{"[A-Z]*", "file.c", FnmCaseFold, true},
+
+		{"{a,b}", "a", 0, false},
+		{"{a,b}", "{a,b}", 0, true},
+		{"!a", "!a", 0, true},
+		{"[a", "[a", 0, true},
+		{"[!", "[!", 0, true},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			ok, err := Fnmatch(tc.Pattern, tc.Name, tc.Flags)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ok != tc.Match {
+				if tc.Match {
+					t.Fatalf("expected %q to match %q (flags %#x), but it didn't", tc.Name, tc.Pattern, tc.Flags)
+				} else {
+					t.Fatalf("expected %q to not match %q (flags %#x), but it did", tc.Name, tc.Pattern, tc.Flags)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"fmt"
+	"reflect"
+	"text/template"
+)
+
+// FuncMap returns functions exposing the functionality of this package to
+// text/template templates:
+//
+//  - {{ quote .Args }} quotes its arguments with Quote. Arguments may be
+//    strings or string slices, which are flattened into a single argv.
+//  - {{ glob "x/**" .Path }} returns whether the path matches the pattern.
+//  - {{ subst "${name}" .Vars }} substitutes variables with Substitute. The
+//    variables may be a VariableMap, a map[string]string, or a struct as
+//    accepted by StructVars.
+//
+// The returned map can be converted to an html/template.FuncMap.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"quote": templateQuote,
+		"glob":  GlobMatch,
+		"subst": templateSubst,
+	}
+}
+
+func templateQuote(args ...interface{}) (string, error) {
+	var argv []string
+	for _, arg := range args {
+		switch arg := arg.(type) {
+		case string:
+			argv = append(argv, arg)
+		case []string:
+			argv = append(argv, arg...)
+		default:
+			return "", fmt.Errorf("quote: unsupported argument type %T", arg)
+		}
+	}
+	return Quote(argv), nil
+}
+
+func templateSubst(s string, vars interface{}) (string, error) {
+	var vmap VariableMap
+	switch v := vars.(type) {
+	case VariableMap:
+		vmap = v
+	case map[string]string:
+		vmap = SimpleVariableMap(v)
+	default:
+		rv := reflect.ValueOf(vars)
+		for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			rv = rv.Elem()
+		}
+		if rv.Kind() != reflect.Struct {
+			return "", fmt.Errorf("subst: unsupported variables type %T", vars)
+		}
+		vmap = StructVars(rv.Interface())
+	}
+	return Substitute(s, vmap)
+}
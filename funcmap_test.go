@@ -0,0 +1,64 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestFuncMap(t *testing.T) {
+	data := struct {
+		Args []string
+		Path string
+		Vars map[string]string
+		Conf struct{ Arch string }
+	}{
+		Args: []string{"rm", "abc def"},
+		Path: "x/y/z",
+		Vars: map[string]string{"name": "world"},
+	}
+	data.Conf.Arch = "amd64"
+
+	tcases := []struct {
+		Template, Expected string
+	}{
+		{`{{ quote .Args }}`, `rm 'abc def'`},
+		{`{{ quote "ls" .Args "-l" }}`, `ls rm 'abc def' -l`},
+		{`{{ glob "x/**" .Path }}`, `true`},
+		{`{{ if glob "y/**" .Path }}yes{{ else }}no{{ end }}`, `no`},
+		{`{{ subst "hello ${name}" .Vars }}`, `hello world`},
+		{`{{ subst "${Arch}" .Conf }}`, `amd64`},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Template, func(t *testing.T) {
+			tmpl := template.Must(template.New("").Funcs(FuncMap()).Parse(tc.Template))
+			var out strings.Builder
+			if err := tmpl.Execute(&out, data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := out.String(); actual != tc.Expected {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+		})
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		for _, text := range []string{
+			`{{ quote 42 }}`,
+			`{{ glob "[" .Path }}`,
+			`{{ subst "${undefined}" .Vars }}`,
+			`{{ subst "${name}" 42 }}`,
+		} {
+			tmpl := template.Must(template.New("").Funcs(FuncMap()).Parse(text))
+			if err := tmpl.Execute(&strings.Builder{}, data); err == nil {
+				t.Errorf("%s: expected error", text)
+			}
+		}
+	})
+}
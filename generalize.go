@@ -0,0 +1,135 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// GeneralizeFrom proposes a pattern matching all the example paths, as
+// specific as possible, such as a rule ignoring files a user selected:
+//
+//  - Paths that all have the same number of components are generalized
+//    component by component: components that differ are replaced by a star,
+//    keeping their common prefix and suffix. "src/a.go" and "lib/b.go"
+//    give "*/*.go".
+//  - Otherwise, the leading components all paths have in common are kept,
+//    followed by "**/" and the generalization of the final components.
+//    "src/a.go" and "src/x/y/b.go" give "src/**/*.go".
+//
+// Literal parts are quoted with QuoteGlobMeta. A trailing slash, denoting
+// directories, is kept if all paths have one, and made optional if only
+// some do. GeneralizeFrom returns "" if paths is empty.
+func GeneralizeFrom(paths []string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+	dirs, someDirs := true, false
+	split := make([][]string, len(paths))
+	minLen, maxLen := -1, 0
+	for i, path := range paths {
+		trimmed := strings.TrimSuffix(path, "/")
+		dirs = dirs && trimmed != path
+		someDirs = someDirs || trimmed != path
+		split[i] = strings.Split(trimmed, "/")
+		if n := len(split[i]); minLen == -1 || n < minLen {
+			minLen = n
+		}
+		maxLen = max(maxLen, len(split[i]))
+	}
+
+	var b strings.Builder
+	if minLen == maxLen {
+		for i := 0; i < minLen; i++ {
+			if i > 0 {
+				b.WriteByte('/')
+			}
+			b.WriteString(generalizeComponent(split, i))
+		}
+	} else {
+		// Keep at least the final component out of the common prefix.
+		for i := 0; i < minLen-1; i++ {
+			component := split[0][i]
+			common := true
+			for _, s := range split[1:] {
+				common = common && s[i] == component
+			}
+			if !common {
+				break
+			}
+			b.WriteString(QuoteGlobMeta(component))
+			b.WriteByte('/')
+		}
+		b.WriteString("**/")
+		b.WriteString(generalizeComponent(split, -1))
+	}
+	if dirs {
+		b.WriteByte('/')
+	} else if someDirs {
+		b.WriteString("{,/}")
+	}
+	return b.String()
+}
+
+// generalizeComponent returns a pattern matching the components at index i
+// of all split paths, counted from the end if i is negative.
+func generalizeComponent(split [][]string, i int) string {
+	components := make([]string, len(split))
+	for j, s := range split {
+		if i < 0 {
+			components[j] = s[len(s)+i]
+		} else {
+			components[j] = s[i]
+		}
+	}
+
+	prefix := components[0]
+	for _, c := range components[1:] {
+		prefix = prefix[:commonPrefixLen(prefix, c)]
+	}
+	all := true
+	for _, c := range components {
+		all = all && c == prefix
+	}
+	if all {
+		return QuoteGlobMeta(prefix)
+	}
+
+	// The suffix must not overlap the prefix in any component.
+	suffix := components[0][len(prefix):]
+	for _, c := range components[1:] {
+		c = c[len(prefix):]
+		suffix = suffix[len(suffix)-commonSuffixLen(suffix, c):]
+	}
+	return QuoteGlobMeta(prefix) + "*" + QuoteGlobMeta(suffix)
+}
+
+// commonPrefixLen returns the length of the longest common prefix of a and
+// b, ending at a rune boundary.
+func commonPrefixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	for n > 0 && n < len(a) && !utf8.RuneStart(a[n]) {
+		n--
+	}
+	return n
+}
+
+// commonSuffixLen returns the length of the longest common suffix of a and
+// b, starting at a rune boundary.
+func commonSuffixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[len(a)-1-n] == b[len(b)-1-n] {
+		n++
+	}
+	for n > 0 && !utf8.RuneStart(a[len(a)-n]) {
+		n--
+	}
+	return n
+}
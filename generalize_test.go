@@ -0,0 +1,49 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGeneralizeFrom(t *testing.T) {
+	tcases := []struct {
+		Paths    []string
+		Expected string
+	}{
+		{nil, ""},
+		{[]string{"src/main.go"}, "src/main.go"},
+		{[]string{"src/main.go", "src/main.go"}, "src/main.go"},
+		{[]string{"src/a.go", "src/b.go"}, "src/*.go"},
+		{[]string{"src/a.go", "lib/b.go"}, "*/*.go"},
+		{[]string{"log/app-1.log", "log/app-22.log"}, "log/app-*.log"},
+		{[]string{"aa", "aaa"}, "aa*"},
+		{[]string{"a.go", "a.md"}, "a.*"},
+		{[]string{"x", "y"}, "*"},
+		{[]string{"src/a.go", "src/x/y/b.go"}, "src/**/*.go"},
+		{[]string{"a/b/c.txt", "d.txt"}, "**/*.txt"},
+		{[]string{"build/", "dist/"}, "*/"},
+		{[]string{"build/", "dist"}, "*{,/}"},
+		{[]string{"a[1].txt", "a[2].txt"}, `a\[*\].txt`},
+		{[]string{"café.txt", "cafè.txt"}, "caf*.txt"},
+	}
+
+	for _, tc := range tcases {
+		t.Run(fmt.Sprint(tc.Paths), func(t *testing.T) {
+			actual := GeneralizeFrom(tc.Paths)
+			if actual != tc.Expected {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+			g := MustCompileGlob(actual)
+			for _, path := range tc.Paths {
+				if !g.Match(path) {
+					t.Errorf("%q does not match %q", path, actual)
+				}
+			}
+		})
+	}
+}
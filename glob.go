@@ -8,14 +8,25 @@ package shutil
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
-	"regexp"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"unicode/utf8"
 )
 
 var (
 	ErrUnterminatedClass = errors.New("unterminated character class")
+	ErrUnknownClass      = errors.New("unknown character class")
+	ErrUnknownCollating  = errors.New("unknown collating element")
+	ErrInvalidRange      = errors.New("invalid character range")
+	ErrUnterminatedBrace = errors.New("unterminated brace group")
+	ErrUnexpectedBrace   = errors.New("unexpected closing brace")
+	ErrEmptyBrace        = errors.New("empty brace group")
+	ErrTrailingBackslash = errors.New("trailing backslash")
+	ErrInvalidSeparator  = errors.New("invalid separator")
+	ErrInvalidUTF8       = errors.New("invalid UTF-8")
 )
 
 // GlobError represents a syntax error for a specific glob pattern.
@@ -50,15 +61,59 @@ type globParser struct {
 	index, width int
 	neg          bool
 	err          error
-	out          strings.Builder
-	choiceNest   int
+
+	// seq is the sequence of nodes being parsed, and groups holds the
+	// enclosing brace groups.
+	seq    []node
+	groups []braceGroup
+
+	// flags is a combination of the Fnm* flags altering the syntax and
+	// semantics of the pattern.
+	flags int
+
+	// fnmatch restricts the syntax to that of fnmatch(3): brace expansion,
+	// "**" and pattern negation are disabled.
+	fnmatch bool
+
+	// segments holds the indices in the pattern where path components start.
+	// It is only tracked when FnmPathname is set.
+	segments []int
+
+	// tokens holds the custom tokens recognized by the parser, and depth the
+	// number of token expansions the parser is nested in.
+	tokens map[string]TokenFunc
+	depth  int
+
+	// literals and wildcards count the literal characters and the wildcards
+	// ("?", "*", "**" and bracket expressions) of the pattern.
+	literals, wildcards int
+
+	// rawBytes makes the parser decode invalid UTF-8 as raw bytes, as
+	// described in UTF8Bytes, while strictUTF8 makes token expansions
+	// containing some invalid, as described in UTF8Strict.
+	rawBytes   bool
+	strictUTF8 bool
+
+	// sep is the custom separator swapped with "/" in the pattern, if any,
+	// and in the expansions of tokens as well.
+	sep rune
+
+	// prefix holds the literal characters the pattern starts with, until
+	// the first special construct, at which point inPrefix becomes false.
+	prefix   strings.Builder
+	inPrefix bool
 }
 
 func (l *globParser) next() (r rune) {
-	r, l.width = utf8.DecodeRuneInString(l.in[l.index:])
-	if l.width == 0 {
+	if l.index == len(l.in) {
+		l.width = 0
 		return eof
 	}
+	mode := UTF8Replace
+	if l.rawBytes {
+		mode = UTF8Bytes
+	}
+	r, l.width = decodeRune(l.in[l.index:], mode)
 	l.index += l.width
 	return r
 }
@@ -74,6 +129,54 @@ func (l *globParser) peek() rune {
 	return r
 }
 
+func (l *globParser) escapes() bool {
+	return l.flags&FnmNoEscape == 0
+}
+
+func (l *globParser) pathname() bool {
+	return l.flags&FnmPathname != 0
+}
+
+// braceGroup is a brace group being parsed.
+type braceGroup struct {
+
+	// index is the index of the opening brace in the pattern.
+	index int
+
+	// outer is the sequence of nodes preceding the group.
+	outer []node
+
+	// alts holds the alternatives of the group parsed so far.
+	alts [][]node
+}
+
+func (l *globParser) emit(n node) {
+	l.seq = append(l.seq, n)
+}
+
+func (l *globParser) parse() ([]node, error) {
+	if l.pathname() {
+		l.segments = append(l.segments, 0)
+	}
+	l.inPrefix = true
+	l.run()
+	if l.err != nil {
+		return nil, l.err
+	}
+	return l.seq, nil
+}
+
+// run runs the parser until the end of the input, and checks that all brace
+// groups were closed.
+func (l *globParser) run() {
+	for state := parseMain; state != nil; state = state(l) {
+		continue
+	}
+	if l.err == nil && len(l.groups) != 0 {
+		l.err = &GlobError{Pattern: l.in, Index: l.groups[len(l.groups)-1].index, Err: ErrUnterminatedBrace}
+	}
+}
+
 func parseMain(p *globParser) parseFunc {
 	r := p.next()
 
@@ -81,139 +184,809 @@ func parseMain(p *globParser) parseFunc {
 	case eof:
 		return nil
 	case '\\':
-		if next := p.next(); next == eof {
+		if !p.escapes() {
 			goto literal
-		} else {
-			p.out.WriteRune(next)
 		}
+		if p.peek() == eof && !p.fnmatch {
+			p.err = &GlobError{Pattern: p.in, Index: p.index - len(`\`), Err: ErrTrailingBackslash}
+			return nil
+		}
+		if next := p.next(); next != eof {
+			r = next
+		}
+		goto literal
 	case '!':
-		if p.index-p.width != 0 {
+		if p.fnmatch || p.depth > 0 || p.index-p.width != 0 {
 			goto literal
 		}
 		p.neg = !p.neg
-	case '.', '(', ')', '^', '$', '|', '+':
-		p.out.WriteRune('\\')
-		goto literal
 	case '{':
-		p.out.WriteRune('(')
-		p.choiceNest++
+		if p.fnmatch {
+			goto literal
+		}
+		if from, to, n, ok := braceRange(p.in[p.index:]); ok {
+			if from > to {
+				from, to = to, from
+			}
+			p.emit(node{op: nodeClass, class: &charClass{ranges: []runeRange{{from, to}}}, capture: true})
+			p.index += n
+			p.wildcards++
+			break
+		}
+		p.groups = append(p.groups, braceGroup{index: p.index - p.width, outer: p.seq})
+		p.seq = nil
 	case ',':
-		if p.choiceNest == 0 {
+		if len(p.groups) == 0 {
 			goto literal
 		}
-		p.out.WriteRune('|')
+		group := &p.groups[len(p.groups)-1]
+		group.alts = append(group.alts, p.seq)
+		p.seq = nil
 	case '}':
-		if p.choiceNest == 0 {
+		if p.fnmatch {
 			goto literal
 		}
-		p.out.WriteRune(')')
-		p.choiceNest--
+		if len(p.groups) == 0 {
+			p.err = &GlobError{Pattern: p.in, Index: p.index - p.width, Err: ErrUnexpectedBrace}
+			return nil
+		}
+		group := p.groups[len(p.groups)-1]
+		if group.index == p.index-p.width-len("{") {
+			p.err = &GlobError{Pattern: p.in, Index: group.index, Err: ErrEmptyBrace}
+			return nil
+		}
+		p.groups = p.groups[:len(p.groups)-1]
+		p.seq = append(group.outer, node{op: nodeAlt, alts: append(group.alts, p.seq), capture: true})
 	case '[':
+		p.inPrefix = false
 		return parseClass
+	case '%':
+		if p.tokens == nil || p.peek() != '{' {
+			goto literal
+		}
+		p.inPrefix = false
+		return parseToken
 	case '?':
-		p.out.WriteString(`[^/]`)
+		p.wildcards++
+		p.emit(node{op: nodeClass, class: p.anyClass(), capture: true})
 	case '*':
-		if strings.HasPrefix(p.in[p.index:], `*/`) {
+		p.wildcards++
+		if p.fnmatch || !p.pathname() {
+			// Consecutive stars have no special meaning in fnmatch(3), nor
+			// when stars match slashes anyway.
+			for p.peek() == '*' {
+				p.next()
+			}
+			p.emit(node{op: nodeStar, class: p.anyClass(), capture: true})
+		} else if strings.HasPrefix(p.in[p.index:], `*/`) {
 			// we either have **/ or /**/ -- this means match zero or more
 			// leading directories.
-			p.out.WriteString(`(|[^\0]*/)`)
+			p.emit(optional(node{op: nodeStar, class: anyRune, capture: true}, node{op: nodeRune, r: '/'}))
 			p.index += len(`*/`)
 		} else if p.peek() == '*' {
 			// we either have /** or ** -- the former means "anything under X",
 			// while the latter means "everything", both including nothing.
-			p.out.WriteString(`[^\0]*/?`)
+			p.emit(node{op: nodeStar, class: anyRune, capture: true})
 			p.next()
 		} else if p.peek() == '/' {
-			p.out.WriteString(`([^/]*/)?`)
+			p.emit(optional(node{op: nodeStar, class: anyButSlash, capture: true}, node{op: nodeRune, r: '/'}))
 			p.next()
 		} else {
-			p.out.WriteString(`[^/]*`)
+			p.emit(node{op: nodeStar, class: anyButSlash, capture: true})
 		}
 	default:
 		goto literal
 	}
+	p.inPrefix = false
 	return parseMain
 
 literal:
-	p.out.WriteRune(r)
+	p.emit(node{op: nodeRune, r: r})
+	p.literals++
+	if p.inPrefix {
+		writeRune(&p.prefix, r)
+	}
+	if r == '/' && p.pathname() {
+		p.segments = append(p.segments, p.index)
+	}
 	return parseMain
 }
 
+// runeRange is an inclusive range of runes in a character class.
+type runeRange struct {
+	lo, hi rune
+}
+
+// posixClasses holds the character classes that can be used in bracket
+// expressions, as in "[[:alpha:]]". They are defined for the POSIX locale.
+var posixClasses = map[string][]runeRange{
+	"alnum":  {{'0', '9'}, {'A', 'Z'}, {'a', 'z'}},
+	"alpha":  {{'A', 'Z'}, {'a', 'z'}},
+	"blank":  {{'\t', '\t'}, {' ', ' '}},
+	"cntrl":  {{0x00, 0x1f}, {0x7f, 0x7f}},
+	"digit":  {{'0', '9'}},
+	"graph":  {{0x21, 0x7e}},
+	"lower":  {{'a', 'z'}},
+	"print":  {{0x20, 0x7e}},
+	"punct":  {{0x21, 0x2f}, {0x3a, 0x40}, {0x5b, 0x60}, {0x7b, 0x7e}},
+	"space":  {{'\t', '\r'}, {' ', ' '}},
+	"upper":  {{'A', 'Z'}},
+	"xdigit": {{'0', '9'}, {'A', 'F'}, {'a', 'f'}},
+}
+
+// charClass is a parsed bracket expression.
+type charClass struct {
+	ranges  []runeRange
+	negated bool
+}
+
+// exclude removes r from the set of runes matched by the class.
+func (c *charClass) exclude(r rune) {
+	if c.negated {
+		c.ranges = append(c.ranges, runeRange{r, r})
+		return
+	}
+	ranges := c.ranges[:0:0]
+	for _, rg := range c.ranges {
+		if r < rg.lo || r > rg.hi {
+			ranges = append(ranges, rg)
+			continue
+		}
+		if rg.lo < r {
+			ranges = append(ranges, runeRange{rg.lo, r - 1})
+		}
+		if r < rg.hi {
+			ranges = append(ranges, runeRange{r + 1, rg.hi})
+		}
+	}
+	c.ranges = ranges
+}
+
+// matches returns whether the class matches r.
+func (c *charClass) matches(r rune) bool {
+	return c.contains(r) != c.negated
+}
+
+// contains returns whether r is in one of the ranges of the class.
+func (c *charClass) contains(r rune) bool {
+	for _, rg := range c.ranges {
+		if rg.lo <= r && r <= rg.hi {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// anyRune matches any rune.
+	anyRune = &charClass{negated: true}
+
+	// anyButSlash matches any rune but "/".
+	anyButSlash = &charClass{negated: true, ranges: []runeRange{{'/', '/'}}}
+)
+
+// anyClass returns the class of runes matched by "?".
+func (l *globParser) anyClass() *charClass {
+	if l.pathname() {
+		return anyButSlash
+	}
+	return anyRune
+}
+
+// braceRange parses the "X..Y}" character range following an opening brace
+// at the start of s. It returns the bounds of the range, and the length of
+// the range in s.
+func braceRange(s string) (from, to rune, n int, ok bool) {
+	from, w1 := utf8.DecodeRuneInString(s)
+	if w1 == 0 || from == '\\' || !strings.HasPrefix(s[w1:], "..") {
+		return 0, 0, 0, false
+	}
+	to, w2 := utf8.DecodeRuneInString(s[w1+len(".."):])
+	n = w1 + len("..") + w2
+	if w2 == 0 || to == '\\' || !strings.HasPrefix(s[n:], "}") {
+		return 0, 0, 0, false
+	}
+	return from, to, n + len("}"), true
+}
+
 func parseClass(p *globParser) parseFunc {
-	p.out.WriteRune('[')
-	start := p.index
-	for {
-		r := p.next()
+	open := p.index - p.width
+	p.wildcards++
 
-		switch r {
-		case eof:
-			p.err = &GlobError{Pattern: p.in, Index: p.index, Err: ErrUnterminatedClass}
+	var class charClass
+	if r := p.peek(); r == '!' || r == '^' {
+		p.next()
+		class.negated = true
+	}
+
+	for first := true; ; first = false {
+		if p.peek() == ']' && !first {
+			p.next()
+			break
+		}
+		if strings.HasPrefix(p.in[p.index:], "[:") {
+			if end := strings.Index(p.in[p.index+len("[:"):], ":]"); end != -1 {
+				name := p.in[p.index+len("[:") : p.index+len("[:")+end]
+				ranges, ok := posixClasses[name]
+				if !ok {
+					p.err = &GlobError{Pattern: p.in, Index: p.index, Err: ErrUnknownClass}
+					return nil
+				}
+				class.ranges = append(class.ranges, ranges...)
+				p.index += len("[:") + end + len(":]")
+				p.width = 0
+				continue
+			}
+		}
+		if r, n, ok := collatingElement(p.in[p.index:], '='); n > 0 {
+			if !ok {
+				p.err = &GlobError{Pattern: p.in, Index: p.index, Err: ErrUnknownCollating}
+				return nil
+			}
+			class.ranges = append(class.ranges, runeRange{r, r})
+			p.index += n
+			p.width = 0
+			continue
+		}
+		lo, ok := p.classBound()
+		if p.err != nil {
 			return nil
-		case '\\':
-			switch next := p.next(); next {
-			case eof:
-				goto literal
-			case '\\', '-', '^', '[', ']':
-				// We still need to escape these
-				p.out.WriteRune('\\')
-				p.out.WriteRune(next)
-			default:
-				p.out.WriteRune(next)
+		}
+		if !ok {
+			if p.fnmatch {
+				// fnmatch(3) treats an unterminated bracket as a literal '['.
+				p.index = open + len(`[`)
+				p.emit(node{op: nodeRune, r: '['})
+				p.wildcards--
+				p.literals++
+				return parseMain
 			}
-		case '!':
-			if p.index-start-p.width == 0 {
-				p.out.WriteRune('^')
+			p.err = &GlobError{Pattern: p.in, Index: open, Err: ErrUnterminatedClass}
+			return nil
+		}
+
+		hi := lo
+		if save := p.index; p.next() == '-' {
+			if p.peek() == ']' {
+				p.index = save
+			} else if r, ok := p.classBound(); ok {
+				hi = r
+			} else if p.err != nil {
+				return nil
 			} else {
-				goto literal
+				p.index = save
 			}
-		case '[':
-			p.out.WriteRune('\\')
-			p.out.WriteRune(r)
-		case ']':
-			p.out.WriteRune(r)
-			return parseMain
-		default:
-			goto literal
+		} else {
+			p.index = save
 		}
-		continue
+		if lo > hi {
+			p.err = &GlobError{Pattern: p.in, Index: p.index - p.width, Err: ErrInvalidRange}
+			return nil
+		}
+		class.ranges = append(class.ranges, runeRange{lo, hi})
+	}
 
-	literal:
-		p.out.WriteRune(r)
+	if p.fnmatch && p.pathname() {
+		class.exclude('/')
 	}
+	p.emit(node{op: nodeClass, class: &class, capture: true})
+	return parseMain
+}
+
+// classBound reads the next rune of a bracket expression, which can be a
+// collating symbol, as in "[[.-.]]", and is then allowed as a range bound.
+// It returns false if the end of the pattern was reached, or if the
+// collating symbol is invalid, in which case p.err is set.
+func (l *globParser) classBound() (rune, bool) {
+	r, n, ok := collatingElement(l.in[l.index:], '.')
+	if n == 0 {
+		return l.classRune()
+	}
+	if !ok {
+		l.err = &GlobError{Pattern: l.in, Index: l.index, Err: ErrUnknownCollating}
+		return eof, false
+	}
+	l.index += n
+	l.width = n
+	return r, true
+}
+
+// collatingElement parses the collating symbol, as in "[.x.]", or the
+// equivalence class, as in "[=x=]", depending on kind, at the start of s.
+// It returns the rune it denotes, and its length in s, or 0 if s does not
+// start with one. As in the POSIX locale, only single runes are supported,
+// and an equivalence class only contains its rune: it returns false for
+// other elements.
+func collatingElement(s string, kind byte) (r rune, n int, ok bool) {
+	if len(s) < 2 || s[0] != '[' || s[1] != kind {
+		return 0, 0, false
+	}
+	end := strings.Index(s[2:], string(kind)+"]")
+	if end == -1 {
+		return 0, 0, false
+	}
+	elem := s[2 : 2+end]
+	n = 2 + end + 2
+	r, w := utf8.DecodeRuneInString(elem)
+	if w == 0 || w != len(elem) {
+		return 0, n, false
+	}
+	return r, n, true
+}
+
+// classRune reads the next, possibly escaped, rune of a bracket expression.
+// It returns false if the end of the pattern was reached.
+func (l *globParser) classRune() (rune, bool) {
+	r := l.next()
+	if l.width == 0 {
+		return eof, false
+	}
+	if r == '\\' && l.escapes() {
+		if r = l.next(); l.width == 0 {
+			return eof, false
+		}
+	}
+	return r, true
 }
 
 // Glob represents a compiled glob pattern. The supported syntax is mostly the
 // same as glob(7), with the following extensions:
 //
 //  - Curly brace expansion is supported. "{a,b,c}" matches the strings "a", "b", and "c".
+//    Braces must be balanced and not empty; a literal brace can be escaped with a backslash.
+//  - Curly brace character ranges are supported. "{a..d}" matches the strings "a", "b", "c"
+//    and "d".
 //  - A double star ("**") is supported to match any pathname component and their children.
 //    For instance, "dir/*" matches "dir/file" but not "dir/dir/file", while "dir/**" matches both.
+//  - A bracket expression starting with "^" is negated, like one starting with "!", as in
+//    bash: "[^a-z]" matches any character but a lowercase letter.
+//  - Equivalence classes and collating symbols are supported in bracket expressions for
+//    single characters, as in the POSIX locale: "[[=e=]]" matches "e", and "[[.-.]]" matches
+//    "-". Collating symbols can be range bounds, as in "[[.a.]-z]".
 //  - If the pattern starts with "!", the whole pattern is negated. If "!" appears later in the
 //    pattern, it is treated as a literal "!".
 type Glob struct {
 	pattern string
-	re      *regexp.Regexp
+	opts    GlobOptions
+	nodes   []node
+	prog    *program
 	negated bool
+	prefix  string
+
+	literals, wildcards int
+
+	// metrics receives the counters of g, if set when g was compiled.
+	metrics Metrics
+}
+
+// GlobOptions alters the way a pattern is compiled by CompileGlobOptions.
+// The zero value compiles patterns the same way as CompileGlob.
+type GlobOptions struct {
+
+	// CaseInsensitive makes the pattern match regardless of case, including
+	// in character classes: "[A-Z]*" matches "file".
+	CaseInsensitive bool
+
+	// Unanchored makes the pattern match strings that contain a match of the
+	// pattern anywhere, rather than only strings that match the pattern as a
+	// whole: "b?d" matches "abcde".
+	Unanchored bool
+
+	// CleanPath makes the pattern match paths once cleaned: repeated slashes
+	// are collapsed and "." components are removed, such that "dir/*" matches
+	// "./dir//file". Unlike path.Clean, ".." components are kept as is, and a
+	// trailing slash, which denotes a directory, is preserved.
+	CleanPath bool
+
+	// Period makes a leading period in a path component only match a
+	// literal period, like the FnmPeriod flag of Fnmatch and the default
+	// behaviour of shells: "*.log" does not match ".hidden.log", and "**"
+	// does not match paths below hidden directories.
+	Period bool
+
+	// CrossSeparators makes "*", "?" and bracket expressions match "/" like
+	// any other character, as in fnmatch(3) without FNM_PATHNAME. It is
+	// useful to match identifiers, like package names, rather than paths.
+	// "**" has no special meaning in this mode, and is equivalent to "*".
+	CrossSeparators bool
+
+	// TrailingSlash makes the pattern ignore trailing slashes, such that
+	// "dir" matches "dir/", and "dir/" matches "dir". This is useful with
+	// paths, like the names of tar entries, that end with a slash when they
+	// denote directories.
+	TrailingSlash bool
+
+	// MatchBase makes a pattern containing no slash, other than a trailing
+	// one, match the final component of paths, like find -name and
+	// gitignore: "*.go" matches "cmd/tool/main.go". The trailing slash of
+	// paths is kept, such that "*/" matches "cmd/tool/" but not
+	// "cmd/tool/main.go". Other patterns are matched against whole paths, as
+	// usual.
+	MatchBase bool
+
+	// AnchorAnywhere anchors patterns like gitignore does: a pattern
+	// containing no slash, other than a trailing one, matches at any depth,
+	// as if it started with "**/", while a leading slash anchors the pattern
+	// to the root, and is otherwise ignored. "*.o" thus matches "src/main.o",
+	// and "/*.o" matches "main.o" but not "src/main.o". Patterns containing
+	// other slashes are relative to the root, whether they start with a
+	// slash or not.
+	AnchorAnywhere bool
+
+	// Separator replaces "/" as the separator of the components of the
+	// strings matched: "*" and "?" do not match it, "**" matches across it,
+	// and "/" is an ordinary character. For instance, with '.', "a.*"
+	// matches "a.b" but not "a.b.c", while "a.**" matches both, which suits
+	// dotted configuration keys. The separator must be an ASCII character
+	// other than a special character of patterns; zero, the default, means
+	// "/".
+	//
+	// Other options treat the separator as they treat "/". Prefix and Capture
+	// report strings with the separator, but walks and the translations to
+	// SQL and find(1) only support "/".
+	Separator rune
+
+	// SkipHidden makes "**" not match path components starting with a
+	// period, like ".git" or ".cache", which must then be named by the
+	// pattern: "**/*.go" matches "src/main.go" but neither ".git/x.go" nor
+	// "src/.cache/x.go", while ".github/**" matches ".github/ci.yml". Unlike
+	// with Period, other wildcards match leading periods. Walks do not
+	// descend into the hidden directories such patterns cannot match under.
+	SkipHidden bool
+
+	// InvalidUTF8 sets how bytes that are not valid UTF-8 are treated, in the
+	// pattern and in the strings matched. The default, UTF8Replace, treats
+	// them as ranging over strings does. See UTF8Mode for the others.
+	InvalidUTF8 UTF8Mode
+}
+
+// input returns the options altering the strings matched by the glob.
+func (opts GlobOptions) input() inputOptions {
+	in := inputOptions{cleanPath: opts.CleanPath, trailingSlash: opts.TrailingSlash, base: opts.MatchBase, utf8: opts.InvalidUTF8}
+	if opts.Separator != '/' {
+		in.sep = opts.Separator
+	}
+	return in
+}
+
+// inputOptions are the options that are applied to strings before matching
+// them.
+type inputOptions struct {
+	cleanPath     bool
+	trailingSlash bool
+	base          bool
+
+	// sep is the separator of the components of the strings matched, if
+	// not "/". It is swapped with "/" before matching.
+	sep rune
+
+	// utf8 sets how invalid UTF-8 is decoded.
+	utf8 UTF8Mode
+}
+
+// mode returns the mode in which prepared strings must be matched.
+func (in inputOptions) mode(sep rune, fold bool) matchMode {
+	return matchMode{sep: sep, fold: fold, trailingSlash: in.trailingSlash}
+}
+
+// prepare returns data as it must be matched, sep being the path separator.
+func (in inputOptions) prepare(data string, sep rune) string {
+	data = in.swap(data)
+	if in.cleanPath {
+		data = cleanGlobPath(data, sep)
+	}
+	if in.base {
+		data = baseGlobPath(data, sep)
+	}
+	return data
+}
+
+// separator returns the separator of the components of the strings matched.
+func (in inputOptions) separator() rune {
+	if in.sep == 0 {
+		return '/'
+	}
+	return in.sep
+}
+
+// swap swaps the custom separator of in, if any, and "/" in s. Patterns using
+// a custom separator are compiled and matched with the characters swapped,
+// which is reverted by swapping them again.
+func (in inputOptions) swap(s string) string {
+	if in.sep == 0 {
+		return s
+	}
+	return swapRunes(s, in.sep, '/')
+}
+
+// swapRunes replaces a with b and b with a in s.
+func swapRunes(s string, a, b rune) string {
+	if !strings.ContainsRune(s, a) && !strings.ContainsRune(s, b) {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		return swapRune(r, a, b)
+	}, s)
+}
+
+// swapRune returns b if r is a, a if r is b, and r otherwise.
+func swapRune(r, a, b rune) rune {
+	switch r {
+	case a:
+		return b
+	case b:
+		return a
+	}
+	return r
+}
+
+// swapNodes returns a copy of nodes where a and b are swapped, in runes as
+// well as in classes.
+func swapNodes(nodes []node, a, b rune) []node {
+	swapped := make([]node, len(nodes))
+	for i, n := range nodes {
+		switch n.op {
+		case nodeRune:
+			switch n.r {
+			case a:
+				n.r = b
+			case b:
+				n.r = a
+			}
+		case nodeClass, nodeStar:
+			n.class = swapClass(n.class, a, b)
+		case nodeAlt:
+			alts := make([][]node, len(n.alts))
+			for j, alt := range n.alts {
+				alts[j] = swapNodes(alt, a, b)
+			}
+			n.alts = alts
+		}
+		swapped[i] = n
+	}
+	return swapped
+}
+
+// swapClass returns a class matching a where c matches b, and conversely.
+func swapClass(c *charClass, a, b rune) *charClass {
+	hasA, hasB := c.contains(a), c.contains(b)
+	if hasA == hasB {
+		return c
+	}
+	// Remove the rune the class contains, and add the other one.
+	from, to := a, b
+	if hasB {
+		from, to = b, a
+	}
+	swapped := &charClass{ranges: c.ranges}
+	swapped.exclude(from)
+	swapped.ranges = append(swapped.ranges, runeRange{to, to})
+	swapped.negated = c.negated
+	return swapped
+}
+
+// validSeparator returns whether sep can be used as a custom separator.
+func validSeparator(sep rune) bool {
+	return sep > ' ' && sep < utf8.RuneSelf && !strings.ContainsRune(`*?[]{},\%!`, sep)
+}
+
+// baseGlobPath returns the final component of path, including its trailing
+// slash if any. Both "/" and sep are treated as separators.
+func baseGlobPath(path string, sep rune) string {
+	isSep := func(c byte) bool { return c == '/' || rune(c) == sep }
+
+	end := len(path)
+	if end > 0 && isSep(path[end-1]) {
+		end--
+	}
+	for i := end - 1; i >= 0; i-- {
+		if isSep(path[i]) {
+			return path[i+1:]
+		}
+	}
+	return path
+}
+
+// cleanGlobPath removes empty and "." components from path, as well as the
+// final "." component, while preserving a trailing slash. Both "/" and sep
+// are treated as separators.
+func cleanGlobPath(path string, sep rune) string {
+	isSep := func(c byte) bool { return c == '/' || rune(c) == sep }
+
+	clean := true
+	for i := 0; clean && i < len(path); i++ {
+		start := i == 0 || isSep(path[i-1])
+		switch {
+		case start && isSep(path[i]) && i > 0:
+			clean = false
+		case start && path[i] == '.' && (i+1 == len(path) || isSep(path[i+1])):
+			clean = len(path) == 1
+		}
+	}
+	if clean {
+		return path
+	}
+
+	var b strings.Builder
+	if isSep(path[0]) {
+		b.WriteByte('/')
+	}
+	for i := 0; i < len(path); {
+		j := i
+		for j < len(path) && !isSep(path[j]) {
+			j++
+		}
+		if component := path[i:j]; component != "" && component != "." {
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "/") {
+				b.WriteByte('/')
+			}
+			b.WriteString(component)
+		}
+		i = j + 1
+	}
+	switch {
+	case b.Len() == 0:
+		return "."
+	case isSep(path[len(path)-1]) && !strings.HasSuffix(b.String(), "/"):
+		b.WriteByte('/')
+	}
+	return b.String()
 }
 
 // CompileGlob compiles the specified pattern into a Glob object.
 //
 // See the documentation of the Glob type for more details on the supported syntax.
 func CompileGlob(pattern string) (*Glob, error) {
-	p := globParser{in: pattern}
-	p.out.WriteString(`^(?s)`)
-	for state := parseMain; state != nil; state = state(&p) {
-		continue
+	return compileGlob(pattern, GlobOptions{}, nil)
+}
+
+// CompileGlobOptions is like CompileGlob, but compiles the pattern with the
+// specified options.
+func CompileGlobOptions(pattern string, opts GlobOptions) (*Glob, error) {
+	return compileGlob(pattern, opts, nil)
+}
+
+// CompileGlobForOS is like CompileGlob, but matches case-insensitively on
+// operating systems whose filesystems are case-insensitive by default, namely
+// Windows, macOS and iOS. Elsewhere, it is equivalent to CompileGlob.
+func CompileGlobForOS(pattern string) (*Glob, error) {
+	return compileGlob(pattern, GlobOptions{CaseInsensitive: caseInsensitiveOS(runtime.GOOS)}, nil)
+}
+
+// caseInsensitiveOS returns whether filesystems are case-insensitive by
+// default on the goos operating system.
+func caseInsensitiveOS(goos string) bool {
+	switch goos {
+	case "windows", "darwin", "ios":
+		return true
+	}
+	return false
+}
+
+func compileGlob(pattern string, opts GlobOptions, tokens map[string]TokenFunc) (*Glob, error) {
+	if opts.Separator == '/' {
+		opts.Separator = 0
+	}
+	in := opts.input()
+	if in.sep != 0 && !validSeparator(in.sep) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSeparator, in.sep)
+	}
+	if opts.InvalidUTF8 == UTF8Strict && !utf8.ValidString(pattern) {
+		index := 0
+		for index < len(pattern) {
+			r, n := utf8.DecodeRuneInString(pattern[index:])
+			if r == utf8.RuneError && n == 1 {
+				break
+			}
+			index += n
+		}
+		return nil, &GlobError{Pattern: pattern, Index: index, Err: ErrInvalidUTF8}
 	}
-	if p.err != nil {
-		return nil, p.err
+	// The separator is a single byte, like "/", such that swapping them keeps
+	// the indices of errors.
+	swapped := in.swap(pattern)
+
+	p := globParser{
+		in:         swapped,
+		flags:      FnmPathname,
+		tokens:     tokens,
+		rawBytes:   opts.InvalidUTF8 == UTF8Bytes,
+		strictUTF8: opts.InvalidUTF8 == UTF8Strict,
+		sep:        in.sep,
+	}
+	if opts.CrossSeparators {
+		p.flags &^= FnmPathname
+	}
+	if opts.CaseInsensitive {
+		p.flags |= FnmCaseFold
 	}
-	p.out.WriteRune('$')
-	re, err := regexp.Compile(p.out.String())
+	nodes, err := p.parse()
 	if err != nil {
+		var gerr *GlobError
+		if errors.As(err, &gerr) && gerr.Pattern == swapped {
+			gerr.Pattern = pattern
+		}
 		return nil, err
 	}
-	return &Glob{pattern, re, p.neg}, nil
+
+	prefix := p.prefix.String()
+	if strings.Contains(strings.TrimSuffix(swapped, "/"), "/") {
+		// MatchBase only applies to patterns without slashes, other than a
+		// trailing one.
+		opts.MatchBase = false
+		if opts.AnchorAnywhere && len(nodes) > 0 && nodes[0].op == nodeRune && nodes[0].r == '/' {
+			nodes = nodes[1:]
+			prefix = strings.TrimPrefix(prefix, "/")
+		}
+	} else if opts.AnchorAnywhere {
+		nodes = append([]node{optional(node{op: nodeStar, class: anyRune}, node{op: nodeRune, r: '/'})}, nodes...)
+		prefix = ""
+	}
+	if p.neg || opts.Unanchored {
+		prefix = ""
+	}
+	prefix = in.swap(prefix[:strings.LastIndexByte(prefix, '/')+1])
+
+	g := &Glob{
+		pattern:   pattern,
+		opts:      opts,
+		nodes:     nodes,
+		negated:   p.neg,
+		prefix:    prefix,
+		literals:  p.literals,
+		wildcards: p.wildcards,
+		metrics:   currentMetrics(),
+	}
+	g.build()
+	addMetric(MetricGlobsCompiled, 1)
+	return g, nil
+}
+
+// build compiles the program of g from its nodes.
+func (g *Glob) build() {
+	g.prog = &program{utf8: g.opts.InvalidUTF8}
+	g.compileTo(g.prog)
+	if !g.opts.CaseInsensitive && !g.opts.Period && !g.opts.Unanchored && !g.opts.SkipHidden && g.opts.InvalidUTF8 != UTF8Bytes {
+		g.prog.shortcut = newShortcut(g.nodes)
+	}
+}
+
+// compileTo appends the instructions matching g to prog, followed by a match
+// instruction.
+func (g *Glob) compileTo(prog *program) {
+	mode := instMode{
+		fold:       g.opts.CaseInsensitive,
+		period:     g.opts.Period,
+		skipHidden: g.opts.SkipHidden && !g.opts.CrossSeparators,
+	}
+	if g.opts.Unanchored {
+		prog.compile([]node{{op: nodeStar, class: anyRune}}, instMode{fold: mode.fold})
+	}
+	prog.compile(g.nodes, mode)
+	if g.opts.Unanchored {
+		prog.compile([]node{{op: nodeStar, class: anyRune}}, instMode{fold: mode.fold})
+	}
+	prog.emit(inst{op: instMatch})
+}
+
+// CompileGlobs compiles each of the specified patterns into a Glob object.
+//
+// Unlike compiling the patterns one by one, all patterns are compiled even if
+// some are invalid: the returned error joins the errors of every invalid
+// pattern, each prefixed with the index of the pattern.
+func CompileGlobs(patterns []string) ([]*Glob, error) {
+	globs := make([]*Glob, len(patterns))
+	var errs []error
+	for i, pattern := range patterns {
+		var err error
+		if globs[i], err = CompileGlob(pattern); err != nil {
+			errs = append(errs, fmt.Errorf("pattern %d: %w", i, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return globs, nil
 }
 
 // MustCompileGlob is like CompileGlob, but panics if the function returned an error.
@@ -227,7 +1000,118 @@ func MustCompileGlob(pattern string) *Glob {
 
 // Match returns whether data matches the glob pattern.
 func (g *Glob) Match(data string) bool {
-	return g.re.MatchString(data)
+	return g.match(data, '/', false)
+}
+
+func (g *Glob) match(data string, sep rune, fold bool) bool {
+	match := g.matches(data, sep, fold)
+	countMatch(g.metrics, match)
+	return match
+}
+
+// matches is like match, without reporting metrics.
+func (g *Glob) matches(data string, sep rune, fold bool) bool {
+	in := g.opts.input()
+	return g.prog.match(in.prepare(data, sep), in.mode(sep, fold))
+}
+
+// MatchDirPrefix returns whether paths under the directory dir may match
+// the glob pattern, that is, whether a string made of dir, a separator, and
+// any other components may match. The pattern is evaluated component by
+// component, without walking anything: "src/*/gen/**" may match under
+// "src", "src/x" and "src/x/gen", but not under "docs" nor "src/x/y".
+// This allows pruning walks and watches. dir may end with a separator, and
+// the empty string denotes the root, under which all paths are.
+//
+// MatchDirPrefix only returns false if no path under dir can match. It
+// returns true for negated patterns, and for the MatchBase option, under
+// which any path may match.
+func (g *Glob) MatchDirPrefix(dir string) bool {
+	if dir == "" {
+		return true
+	}
+	sep := "/"
+	if g.opts.Separator != 0 {
+		sep = string(g.opts.Separator)
+	}
+	return g.matchPrefix(strings.TrimSuffix(dir, sep) + sep)
+}
+
+// MatchAny returns whether at least one of the strings of data matches the
+// glob pattern. It stops at the first match.
+func (g *Glob) MatchAny(data []string) bool {
+	for _, s := range data {
+		if g.Match(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter partitions data into the strings that match the glob pattern and
+// the ones that do not, both in their original order.
+func (g *Glob) Filter(data []string) (matched, unmatched []string) {
+	for _, s := range data {
+		if g.Match(s) {
+			matched = append(matched, s)
+		} else {
+			unmatched = append(unmatched, s)
+		}
+	}
+	return matched, unmatched
+}
+
+// MatchBytes returns whether b matches the glob pattern. It is equivalent to
+// Match(string(b)), without the conversion.
+func (g *Glob) MatchBytes(b []byte) bool {
+	if g.opts.input() != (inputOptions{}) {
+		return g.Match(string(b))
+	}
+	match := g.prog.matchBytes(b)
+	countMatch(g.metrics, match)
+	return match
+}
+
+// MatchFold returns whether data matches the glob pattern case-insensitively,
+// as if the pattern had been compiled with the CaseInsensitive option. Case
+// is folded the same way as strings.EqualFold does.
+func (g *Glob) MatchFold(data string) bool {
+	return g.match(data, '/', true)
+}
+
+// MatchPrefix returns whether data starts with path components matching the
+// glob pattern, or matches it entirely. Prefixes end at the end of data, or
+// before or after a slash, and never inside a component: "src" matches a
+// prefix of "src/main.go", but not of "srcfoo/x", and "src/*.d" matches a
+// prefix of "src/main.d/file", but not of "src/main.dx/file".
+//
+// Since "*" matches empty strings, "src/*" matches a prefix of any string
+// starting with "src/".
+func (g *Glob) MatchPrefix(data string) bool {
+	in := g.opts.input()
+	base := in.base
+	in.base = false
+	data = in.prepare(data, '/')
+	mode := in.mode('/', false)
+	match := g.prog.matchPrefix(data, mode)
+
+	// With MatchBase, prefixes match if their final component does, which
+	// starts after any of the slashes of data.
+	for i := 0; base && !match && i < len(data); i++ {
+		if data[i] == '/' {
+			match = g.prog.matchPrefix(data[i+1:], mode)
+		}
+	}
+	countMatch(g.metrics, match)
+	return match
+}
+
+// MatchPath returns whether path matches the glob pattern, treating the
+// separator of the operating system as well as "/" as a path separator. This
+// allows matching paths built with the path/filepath package on Windows
+// without converting them with filepath.ToSlash first.
+func (g *Glob) MatchPath(path string) bool {
+	return g.match(path, filepath.Separator, false)
 }
 
 // Match returns whether the specified FileInfo matches the glob pattern.
@@ -245,6 +1129,18 @@ func (g *Glob) MatchInfo(info os.FileInfo) bool {
 	return match
 }
 
+// MatchEntry returns whether the specified directory entry matches the glob
+// pattern. Like MatchInfo, it also checks the name of directories followed by
+// "/", but does not require a call to the Info method of the entry, which
+// makes it suitable for fs.WalkDir callbacks.
+func (g *Glob) MatchEntry(entry fs.DirEntry) bool {
+	match := g.Match(entry.Name())
+	if entry.IsDir() {
+		match = match || g.Match(entry.Name()+"/")
+	}
+	return match
+}
+
 // A Namer represents types that have a Name. Notable types that implement
 // this interface are *os.File and os.FileInfo.
 type Namer interface {
@@ -259,12 +1155,127 @@ func (g *Glob) MatchName(namer Namer) bool {
 	return g.Match(namer.Name())
 }
 
+// Prefix returns the longest leading directory path of the pattern that
+// contains no special characters, including its trailing slash, with escapes
+// removed. Every string matched by the pattern starts with it. For instance,
+// the prefix of "src/gen/**/*.pb.go" is "src/gen/", while the prefix of
+// "*.go" and "!src/**" is the empty string.
+//
+// It is useful to start directory walks at the right place, rather than at
+// the root. Note that for case-insensitive globs, the prefix is returned as
+// written in the pattern.
+func (g *Glob) Prefix() string {
+	return g.prefix
+}
+
+// Literal returns the string matched by the pattern, with escapes removed, if
+// the pattern contains no special characters and thus only matches that
+// string. For instance, Literal returns "src/main.go" for the patterns
+// "src/main.go" and `src/\main.go`, but returns false for "src/*.go".
+//
+// Negated, case-insensitive and unanchored patterns are never literal, nor
+// are patterns matching the final component of paths with MatchBase. With
+// CleanPath, patterns that are not clean paths themselves, like "./dir",
+// match no string, and are not literal either.
+//
+// This allows callers to look the path up directly with os.Stat, rather than
+// walking a directory tree.
+func (g *Glob) Literal() (string, bool) {
+	if g.negated || g.opts.CaseInsensitive || g.opts.Unanchored || g.opts.MatchBase {
+		return "", false
+	}
+	var b strings.Builder
+	for _, n := range g.nodes {
+		if n.op != nodeRune {
+			return "", false
+		}
+		writeRune(&b, n.r)
+	}
+	literal := b.String()
+	if g.opts.CleanPath && cleanGlobPath(literal, '/') != literal {
+		return "", false
+	}
+	return g.opts.input().swap(literal), true
+}
+
+// IsLiteral returns whether the pattern contains no special characters. See
+// Literal for details.
+func (g *Glob) IsLiteral() bool {
+	_, ok := g.Literal()
+	return ok
+}
+
+// NumLiterals returns the number of literal characters of the pattern,
+// escapes excluded. Literals in brace groups are all counted.
+func (g *Glob) NumLiterals() int {
+	return g.literals
+}
+
+// NumWildcards returns the number of wildcards of the pattern: "?", "*",
+// "**", classes and brace ranges. Brace groups are not wildcards by
+// themselves.
+func (g *Glob) NumWildcards() int {
+	return g.wildcards
+}
+
+// CompareSpecificity orders globs from the most specific to the least
+// specific: it returns a negative number if a is more specific than b, a
+// positive number if b is more specific than a, and zero if both are as
+// specific. Patterns with more literal characters are more specific, then
+// patterns with fewer wildcards. This is the order in which GlobMux tries
+// patterns, and allows sorting overlapping rules with slices.SortStableFunc.
+func CompareSpecificity(a, b *Glob) int {
+	if a.literals != b.literals {
+		return b.literals - a.literals
+	}
+	return a.wildcards - b.wildcards
+}
+
+// Programs matching the non-empty strings that denote directories, which end
+// with "/", and the ones that do not.
+var (
+	dirProg    = tailProgram(&charClass{ranges: []runeRange{{'/', '/'}}})
+	nonDirProg = tailProgram(anyButSlash)
+)
+
+func tailProgram(last *charClass) *program {
+	prog := new(program)
+	prog.compile([]node{{op: nodeStar, class: anyRune}, {op: nodeClass, class: last}}, instMode{})
+	prog.emit(inst{op: instMatch})
+	return prog
+}
+
+// Negated returns whether the pattern starts with "!". Negated patterns are
+// exclusions in a GlobSet.
+func (g *Glob) Negated() bool {
+	return g.negated
+}
+
+// DirOnly returns whether the pattern only matches strings ending with "/",
+// which MatchInfo and Walk use to denote directories. For instance, "*/" and
+// "src/{a,b}/" are directory-only patterns, while "src/*" is not. The empty
+// string, which is never the name of a file, is not taken into account.
+//
+// Negated patterns are never directory-only, and neither are patterns
+// compiled with TrailingSlash, which match strings with or without one.
+func (g *Glob) DirOnly() bool {
+	if g.negated || g.opts.TrailingSlash {
+		return false
+	}
+	_, dir := overlap(inputMachine{prog: g.prog}, inputMachine{prog: dirProg})
+	_, nonDir := overlap(inputMachine{prog: g.prog}, inputMachine{prog: nonDirProg})
+	return dir && !nonDir
+}
+
 func (g *Glob) String() string {
 	return g.pattern
 }
 
+// UnmarshalText compiles text into g, with the options g was compiled with,
+// if any. Together with MarshalText, it allows globs to be embedded in
+// configuration structures decoded with encoding/json and similar packages.
 func (g *Glob) UnmarshalText(text []byte) error {
-	glob, err := CompileGlob(string(text))
+	glob, err := CompileGlobOptions(string(text), g.opts)
 	if err != nil {
 		return err
 	}
@@ -272,10 +1283,168 @@ func (g *Glob) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// MarshalText returns the pattern g was compiled from.
 func (g *Glob) MarshalText() ([]byte, error) {
 	return []byte(g.String()), nil
 }
 
+// QuoteGlobMeta returns a pattern matching s literally, by escaping all the
+// special characters of s with backslashes. The result can be embedded in a
+// larger pattern, as in "src/" + QuoteGlobMeta(name) + "/**".
+func QuoteGlobMeta(s string) string {
+	if !strings.ContainsAny(s, `\*?[]{},!`) {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		b.WriteString(escapeGlobRune(r))
+	}
+	return b.String()
+}
+
+// SplitPattern splits pattern into the directory preceding its first special
+// character, and the pattern matching paths relative to that directory. For
+// instance, "src/gen/**/*.go" is split into "src/gen" and "**/*.go".
+//
+// The directory has its escapes removed, such that it can be passed to
+// os.DirFS directly. It is "." if the pattern starts with a special
+// character or contains no slash, and "/" if the pattern starts with the
+// only slash preceding its first special character. Negated patterns are
+// never split.
+func SplitPattern(pattern string) (dir, rest string) {
+	if strings.HasPrefix(pattern, "!") {
+		return ".", pattern
+	}
+	slash := -1
+scan:
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '*', '?', '[', '{':
+			break scan
+		case '/':
+			slash = i
+		}
+	}
+	switch slash {
+	case -1:
+		return ".", pattern
+	case 0:
+		return "/", pattern[1:]
+	}
+
+	dir = pattern[:slash]
+	if strings.ContainsRune(dir, '\\') {
+		var b strings.Builder
+		for i := 0; i < len(dir); i++ {
+			if dir[i] == '\\' && i+1 < len(dir) {
+				i++
+			}
+			b.WriteByte(dir[i])
+		}
+		dir = b.String()
+	}
+	return dir, pattern[slash+1:]
+}
+
+// JoinPattern joins the pattern fragments elem with slashes, like path.Join
+// does with paths, ignoring empty fragments. Slashes at the boundaries of
+// fragments, escaped or not, are collapsed, and so are "**" components
+// ending a fragment and starting the next one: "src/**" and "**/*.go" are
+// joined as "src/**/*.go". A backslash ending a fragment matches a literal
+// backslash, rather than escaping the slash that follows.
+//
+// The first fragment keeps its leading slash and "!", and the last one its
+// trailing slash, if any. Fragments are otherwise kept as they are: special
+// characters of fragments meant to be matched literally must be escaped
+// with QuoteGlobMeta.
+func JoinPattern(elem ...string) string {
+	last := -1
+	for i, e := range elem {
+		if e != "" {
+			last = i
+		}
+	}
+
+	var b strings.Builder
+	for i, e := range elem {
+		if e == "" {
+			continue
+		}
+		first := b.Len() == 0
+		if !first {
+			e = trimLeadingSlashes(e)
+		}
+		trimmed, slash := trimTrailingSlashes(e)
+		if first && trimmed == "" && slash {
+			// Keep the leading slash of absolute patterns.
+			b.WriteByte('/')
+			continue
+		}
+		if !first && trimmed != "" {
+			prev := b.String()
+			if (prev == "**" || strings.HasSuffix(prev, "/**")) && (trimmed == "**" || strings.HasPrefix(trimmed, "**/")) {
+				trimmed = strings.TrimPrefix(strings.TrimPrefix(trimmed, "**"), "/")
+			}
+			if trimmed != "" && !strings.HasSuffix(prev, "/") {
+				b.WriteByte('/')
+			}
+		}
+		b.WriteString(trimmed)
+		if i == last && slash && !strings.HasSuffix(b.String(), "/") {
+			b.WriteByte('/')
+		}
+	}
+	return b.String()
+}
+
+// trimLeadingSlashes removes the slashes, escaped or not, starting s.
+func trimLeadingSlashes(s string) string {
+	for {
+		switch {
+		case strings.HasPrefix(s, "/"):
+			s = s[len("/"):]
+		case strings.HasPrefix(s, `\/`):
+			s = s[len(`\/`):]
+		default:
+			return s
+		}
+	}
+}
+
+// trimTrailingSlashes removes the slashes, escaped or not, ending s, and
+// reports whether there were any. A backslash left at the end of s is
+// escaped.
+func trimTrailingSlashes(s string) (string, bool) {
+	slash := false
+	for strings.HasSuffix(s, "/") {
+		s, slash = s[:len(s)-len("/")], true
+		if trailingBackslashes(s)%2 == 1 {
+			s = s[:len(s)-len(`\`)]
+		}
+	}
+	if trailingBackslashes(s)%2 == 1 {
+		s += `\`
+	}
+	return s, slash
+}
+
+// trailingBackslashes returns the number of backslashes ending s.
+func trailingBackslashes(s string) int {
+	n := 0
+	for n < len(s) && s[len(s)-1-n] == '\\' {
+		n++
+	}
+	return n
+}
+
+// Join returns a glob compiled from the pattern of g joined with elem, as
+// done by JoinPattern, with the same options as g.
+func (g *Glob) Join(elem ...string) (*Glob, error) {
+	return compileGlob(JoinPattern(append([]string{g.pattern}, elem...)...), g.opts, nil)
+}
+
 // GlobMatch compiles pattern, and then returns Glob.Match(data).
 func GlobMatch(pattern, data string) (bool, error) {
 	g, err := CompileGlob(pattern)
@@ -6,8 +6,17 @@
 package shutil
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
 	"strings"
 	"testing"
+	"testing/fstest"
 	"unicode/utf8"
 )
 
@@ -49,6 +58,25 @@ func TestGlobString(t *testing.T) {
 		{"**/file", "/file", true},
 		{"**/file", "y/file", true},
 		{"**/file", "x/y/file", true},
+		{"**", "a\x00b/c", true},
+		{"**/[\x00-\x01]", "x/\x00", true},
+		{"**/[\x00-\x01]/**", "x/\x01/y", true},
+		{"x/**/y", "x/\x00/y", true},
+		{"x/**/y", "x\x00/y", false},
+		{"[^\x00]**", "\x00", false},
+
+		{`\*`, "*", true},
+		{`\*`, "file", false},
+		{`\.`, "x", false},
+		{`\{a,b\}`, "{a,b}", true},
+		{`{a,b\}}`, "b}", true},
+
+		{"shard-{a..d}/*", "shard-c/x", true},
+		{"shard-{a..d}/*", "shard-e/x", false},
+		{"shard-{d..a}", "shard-a", true},
+		{"{0..9}{0..9}", "42", true},
+		{"{a..c}", "a..c", false},
+		{"{Y..b}", `\`, true},
 	}
 
 	t.Run("Simple", func(t *testing.T) {
@@ -58,6 +86,12 @@ func TestGlobString(t *testing.T) {
 				if err != nil {
 					t.Fatalf("unexpected error: %v", err)
 				}
+				if MustCompileGlob(tc.Pattern).MatchBytes([]byte(tc.File)) != ok {
+					t.Fatalf("MatchBytes and Match disagree on %q", tc.File)
+				}
+				if MustCompileGlob(tc.Pattern).Regexp().MatchString(tc.File) != ok {
+					t.Fatalf("Regexp and Match disagree on %q", tc.File)
+				}
 				if ok != tc.Match {
 					if tc.Match {
 						t.Fatalf("expected %q to match %q, but it didn't", tc.File, tc.Pattern)
@@ -88,8 +122,84 @@ func TestGlobString(t *testing.T) {
 		{"[!-z]", "-z", true},
 		{"[!z-]", "-z", true},
 		{"[!]]", "]", true},
+
+		{"[^a-z]", "abcdefghijklmnopqrstuvwxyz", true},
+		{"[^]]", "]", true},
+		{"[a^]", "a^", false},
+		{`[\^a]`, "^a", false},
+
+		{"[[:digit:]]", "0123456789", false},
+		{"[[:xdigit:]]", "0123456789abcdefABCDEF", false},
+		{"[[:upper:][:digit:]]", "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789", false},
+		{"[_[:lower:]]", "_abcdefghijklmnopqrstuvwxyz", false},
+		{"[[:blank:]]", " \t", false},
+		{"[[:space:]]", " \t\n\v\f\r", false},
+		{"[[:punct:]]", "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", false},
+		{"[![:alnum:]]", "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789", true},
+		{"[[:alpha]", "[:alph", false},
+
+		{"[[=e=]]", "e", false},
+		{"[[=e=][=é=]]", "eé", false},
+		{"[![=e=]]", "e", true},
+		{"[[.-.]]", "-", false},
+		{"[[.a.]-c]", "abc", false},
+		{"[x-[.z.]]", "xyz", false},
+		{"[[.]", "[.", false},
 	}
 
+	t.Run("UnknownClass", func(t *testing.T) {
+		_, err := CompileGlob("[[:foo:]]")
+		if !errors.Is(err, ErrUnknownClass) {
+			t.Fatalf("expected %v, got %v", ErrUnknownClass, err)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tcases := []struct {
+			Pattern string
+			Index   int
+			Err     error
+		}{
+			{"{a,b", 0, ErrUnterminatedBrace},
+			{"x/{a,{b}", 2, ErrUnterminatedBrace},
+			{"{a,{b", 3, ErrUnterminatedBrace},
+			{"a}", 1, ErrUnexpectedBrace},
+			{"{a,b}}", 5, ErrUnexpectedBrace},
+			{"a{}", 1, ErrEmptyBrace},
+			{"{a,{}}", 3, ErrEmptyBrace},
+			{"a/b\\", 3, ErrTrailingBackslash},
+			{"x/[ab", 2, ErrUnterminatedClass},
+			{"x/[a\\", 2, ErrUnterminatedClass},
+			{"é[[:foo:]]", 3, ErrUnknownClass},
+			{"x[[=ab=]]", 2, ErrUnknownCollating},
+			{"x[[.hyphen.]]", 2, ErrUnknownCollating},
+			{"x[a-[..]]", 4, ErrUnknownCollating},
+		}
+		for _, tc := range tcases {
+			t.Run(tc.Pattern, func(t *testing.T) {
+				_, err := CompileGlob(tc.Pattern)
+				var gerr *GlobError
+				if !errors.As(err, &gerr) || !errors.Is(err, tc.Err) {
+					t.Fatalf("expected %v, got %v", tc.Err, err)
+				}
+				if gerr.Index != tc.Index {
+					t.Fatalf("expected error at index %d, got %d", tc.Index, gerr.Index)
+				}
+			})
+		}
+	})
+
+	t.Run("InvalidRange", func(t *testing.T) {
+		_, err := CompileGlob("[z-a]")
+		var gerr *GlobError
+		if !errors.As(err, &gerr) || !errors.Is(err, ErrInvalidRange) {
+			t.Fatalf("expected %v, got %v", ErrInvalidRange, err)
+		}
+		if gerr.Index != 3 {
+			t.Fatalf("expected error at index 3, got %d", gerr.Index)
+		}
+	})
+
 	t.Run("Ranges", func(t *testing.T) {
 		for _, tc := range rangeCases {
 			t.Run(tc.Pattern, func(t *testing.T) {
@@ -116,3 +226,1082 @@ func TestGlobString(t *testing.T) {
 		}
 	})
 }
+
+func TestGlobCaseInsensitive(t *testing.T) {
+	tcases := []struct {
+		Pattern, File string
+		Match         bool
+	}{
+		{"file", "FILE", true},
+		{"*.GO", "main.go", true},
+		{"[A-Z]*", "file", true},
+		{"[!a-z]*", "File", false},
+		{"{src,lib}/**", "LIB/x", true},
+		{"file", "fil", false},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, GlobOptions{CaseInsensitive: true})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ok := g.Match(tc.File); ok != tc.Match {
+				if tc.Match {
+					t.Fatalf("expected %q to match %q, but it didn't", tc.File, tc.Pattern)
+				} else {
+					t.Fatalf("expected %q to not match %q, but it did", tc.File, tc.Pattern)
+				}
+			}
+			if ok := MustCompileGlob(tc.Pattern).MatchFold(tc.File); ok != tc.Match {
+				t.Fatalf("expected MatchFold to return %v", tc.Match)
+			}
+		})
+	}
+
+	t.Run("MatchFold", func(t *testing.T) {
+		g := MustCompileGlob("*.go")
+		if !g.MatchFold("MAIN.GO") || g.Match("MAIN.GO") {
+			t.Fatalf("expected MatchFold to only affect its own call")
+		}
+		if MustCompileGlob("straße").MatchFold("STRASSE") {
+			t.Fatalf("expected simple case folding, like strings.EqualFold")
+		}
+		if !MustCompileGlob("k").MatchFold("\u212a") {
+			t.Fatalf("expected the Kelvin sign to fold to k")
+		}
+	})
+
+	t.Run("UnmarshalText", func(t *testing.T) {
+		g, err := CompileGlobOptions("", GlobOptions{CaseInsensitive: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := g.UnmarshalText([]byte("*.go")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !g.Match("MAIN.GO") {
+			t.Fatalf("expected unmarshaled glob to retain case insensitivity")
+		}
+	})
+}
+
+func TestCompileGlobs(t *testing.T) {
+	globs, err := CompileGlobs([]string{"*.go", "docs/**"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(globs) != 2 || globs[0].String() != "*.go" || globs[1].String() != "docs/**" {
+		t.Fatalf("unexpected globs %v", globs)
+	}
+
+	_, err = CompileGlobs([]string{"[a", "*.go", "{a,b", "[[:foo:]]"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, target := range []error{ErrUnterminatedClass, ErrUnterminatedBrace, ErrUnknownClass} {
+		if !errors.Is(err, target) {
+			t.Errorf("expected error to wrap %v", target)
+		}
+	}
+	for _, s := range []string{"pattern 0:", "pattern 2:", "pattern 3:", `"{a,b"`} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("expected %q in error %q", s, err)
+		}
+	}
+	if strings.Contains(err.Error(), "pattern 1:") {
+		t.Errorf("unexpected error for valid pattern: %v", err)
+	}
+}
+
+func TestSplitPattern(t *testing.T) {
+	tcases := []struct {
+		Pattern, Dir, Rest string
+	}{
+		{"a/b/*.go", "a/b", "*.go"},
+		{"src/gen/**/*.pb.go", "src/gen", "**/*.pb.go"},
+		{"*.go", ".", "*.go"},
+		{"main.go", ".", "main.go"},
+		{"a/b/c", "a/b", "c"},
+		{"a/{b,c}/d", "a", "{b,c}/d"},
+		{"a/b[0-9]/c", "a", "b[0-9]/c"},
+		{`a\*b/c/*`, "a*b/c", "*"},
+		{`a\/b/*`, "a/b", "*"},
+		{"/*", "/", "*"},
+		{"/etc/*.conf", "/etc", "*.conf"},
+		{"**/x", ".", "**/x"},
+		{"!a/b/*", ".", "!a/b/*"},
+		{"", ".", ""},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			dir, rest := SplitPattern(tc.Pattern)
+			if dir != tc.Dir || rest != tc.Rest {
+				t.Fatalf("expected %q, %q, got %q, %q", tc.Dir, tc.Rest, dir, rest)
+			}
+		})
+	}
+}
+
+func TestJoinPattern(t *testing.T) {
+	tcases := []struct {
+		Elem     []string
+		Expected string
+	}{
+		{[]string{"src", "*.go"}, "src/*.go"},
+		{[]string{"src/", "/*.go"}, "src/*.go"},
+		{[]string{"src//", "", "//cmd/", "*.go"}, "src/cmd/*.go"},
+		{[]string{"src/**", "**/*.go"}, "src/**/*.go"},
+		{[]string{"src/**/", "/**"}, "src/**"},
+		{[]string{"**", "**", "x"}, "**/x"},
+		{[]string{"src/a**", "**/x"}, "src/a**/**/x"},
+		{[]string{`src\/`, `\/x`}, "src/x"},
+		{[]string{`dir\`, "x"}, `dir\\/x`},
+		{[]string{`dir\\`, "x"}, `dir\\/x`},
+		{[]string{"/", "etc", "*.conf"}, "/etc/*.conf"},
+		{[]string{"!build", "**"}, "!build/**"},
+		{[]string{"a", "!b"}, "a/!b"},
+		{[]string{"a", "b/"}, "a/b/"},
+		{[]string{"a", "b//", ""}, "a/b/"},
+		{[]string{"{a,b}", "*"}, "{a,b}/*"},
+		{[]string{}, ""},
+		{[]string{"", ""}, ""},
+	}
+	for _, tc := range tcases {
+		t.Run(strings.Join(tc.Elem, ","), func(t *testing.T) {
+			if actual := JoinPattern(tc.Elem...); actual != tc.Expected {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+			if _, err := CompileGlob(tc.Expected); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	g, err := MustCompileGlob("src/**").Join("**/*.GO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.String() != "src/**/*.GO" || !g.Match("src/a/main.GO") {
+		t.Fatalf("unexpected glob %q", g)
+	}
+	g, err = CompileGlobOptions("src", GlobOptions{CaseInsensitive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, err = g.Join("*.go"); err != nil || !g.Match("SRC/main.Go") {
+		t.Fatalf("expected joined glob to keep its options")
+	}
+}
+
+func TestQuoteGlobMeta(t *testing.T) {
+	tcases := []struct {
+		In, Out string
+	}{
+		{"", ""},
+		{"main.go", "main.go"},
+		{"*.go", `\*.go`},
+		{"a?b", `a\?b`},
+		{"[ab]", `\[ab\]`},
+		{"{a,b}", `\{a\,b\}`},
+		{"!x", `\!x`},
+		{`a\b`, `a\\b`},
+		{"%{semver}", `%\{semver\}`},
+		{"é/ü", "é/ü"},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.In, func(t *testing.T) {
+			quoted := QuoteGlobMeta(tc.In)
+			if quoted != tc.Out {
+				t.Fatalf("expected %q, got %q", tc.Out, quoted)
+			}
+			g := MustCompileGlob("x/" + quoted + "/**")
+			if !g.Match("x/" + tc.In + "/y") {
+				t.Fatalf("expected %q to match %q", "x/"+tc.In+"/y", g)
+			}
+			if literal, ok := MustCompileGlob(quoted).Literal(); !ok || literal != tc.In {
+				t.Fatalf("expected literal %q, got (%q, %v)", tc.In, literal, ok)
+			}
+		})
+	}
+}
+
+func TestGlobForOS(t *testing.T) {
+	for goos, expected := range map[string]bool{
+		"linux":   false,
+		"freebsd": false,
+		"windows": true,
+		"darwin":  true,
+		"ios":     true,
+	} {
+		if actual := caseInsensitiveOS(goos); actual != expected {
+			t.Errorf("%s: expected %v, got %v", goos, expected, actual)
+		}
+	}
+
+	g, err := CompileGlobForOS("*.GO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if match, expected := g.Match("main.go"), caseInsensitiveOS(runtime.GOOS); match != expected {
+		t.Fatalf("expected %v on %s, got %v", expected, runtime.GOOS, match)
+	}
+}
+
+func TestGlobPrefix(t *testing.T) {
+	tcases := []struct {
+		Pattern, Prefix string
+	}{
+		{"", ""},
+		{"*.go", ""},
+		{"main.go", ""},
+		{"src/main.go", "src/"},
+		{"src/gen/**/*.pb.go", "src/gen/"},
+		{"src/gen*/x", "src/"},
+		{"src/{a,b}/x", "src/"},
+		{"src/[ab]/x", "src/"},
+		{"src/a?/x", "src/"},
+		{`a\*b/c/*`, "a*b/c/"},
+		{`a,b\}/c*`, "a,b}/"},
+		{"/abs/*", "/abs/"},
+		{"!src/**", ""},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			if actual := MustCompileGlob(tc.Pattern).Prefix(); actual != tc.Prefix {
+				t.Fatalf("expected prefix %q, got %q", tc.Prefix, actual)
+			}
+		})
+	}
+}
+
+func TestGlobLiteral(t *testing.T) {
+	tcases := []struct {
+		Pattern string
+		Literal string
+		Ok      bool
+	}{
+		{"", "", true},
+		{"main.go", "main.go", true},
+		{"src/main.go", "src/main.go", true},
+		{`src/\*.go`, "src/*.go", true},
+		{`a\{b\}`, "a{b}", true},
+		{"a,b", "a,b", true},
+		{"x!", "x!", true},
+		{"*.go", "", false},
+		{"src/**", "", false},
+		{"file?", "", false},
+		{"[ab]", "", false},
+		{"{a,b}", "", false},
+		{"{a..c}", "", false},
+		{"!main.go", "", false},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			g := MustCompileGlob(tc.Pattern)
+			literal, ok := g.Literal()
+			if literal != tc.Literal || ok != tc.Ok {
+				t.Fatalf("expected (%q, %v), got (%q, %v)", tc.Literal, tc.Ok, literal, ok)
+			}
+			if g.IsLiteral() != tc.Ok {
+				t.Fatalf("expected IsLiteral to return %v", tc.Ok)
+			}
+		})
+	}
+
+	g, err := CompileGlobOptions("main.go", GlobOptions{CaseInsensitive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.IsLiteral() {
+		t.Fatalf("expected case-insensitive glob to not be literal")
+	}
+
+	for pattern, ok := range map[string]bool{"dir/file": true, "dir/": true, "./dir": false, "a//b": false} {
+		g, err := CompileGlobOptions(pattern, GlobOptions{CleanPath: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if literal, isLiteral := g.Literal(); isLiteral != ok || isLiteral && !g.Match(literal) {
+			t.Fatalf("%s: expected clean literal %v, got (%q, %v)", pattern, ok, literal, isLiteral)
+		}
+	}
+}
+
+func TestGlobSpecificity(t *testing.T) {
+	tcases := []struct {
+		Pattern             string
+		Literals, Wildcards int
+	}{
+		{"", 0, 0},
+		{"src/main.go", 11, 0},
+		{`src/\*.go`, 8, 0},
+		{"src/*.go", 7, 1},
+		{"src/**/*.go", 7, 2},
+		{"[abc]?", 0, 2},
+		{"{a,bc}", 3, 0},
+		{"{1..9}.txt", 4, 1},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			g := MustCompileGlob(tc.Pattern)
+			if n := g.NumLiterals(); n != tc.Literals {
+				t.Errorf("expected %d literals, got %d", tc.Literals, n)
+			}
+			if n := g.NumWildcards(); n != tc.Wildcards {
+				t.Errorf("expected %d wildcards, got %d", tc.Wildcards, n)
+			}
+		})
+	}
+
+	globs := []*Glob{
+		MustCompileGlob("**"),
+		MustCompileGlob("src/**"),
+		MustCompileGlob("src/*.go"),
+		MustCompileGlob("src/main.go"),
+		MustCompileGlob("src/?.go"),
+		MustCompileGlob("**/*.go"),
+	}
+	slices.SortStableFunc(globs, CompareSpecificity)
+	var actual []string
+	for _, g := range globs {
+		actual = append(actual, g.String())
+	}
+	expected := []string{"src/main.go", "src/*.go", "src/?.go", "src/**", "**/*.go", "**"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestGlobDirOnly(t *testing.T) {
+	tcases := []struct {
+		Pattern string
+		Opts    GlobOptions
+		DirOnly bool
+	}{
+		{"*/", GlobOptions{}, true},
+		{"src/{a,b}/", GlobOptions{}, true},
+		{"**/testdata/", GlobOptions{}, true},
+		{"{a/,b/}", GlobOptions{}, true},
+		{"src/*", GlobOptions{}, false},
+		{"src/**", GlobOptions{}, false},
+		{"{a/,b}", GlobOptions{}, false},
+		{"", GlobOptions{}, false},
+		{"*/*", GlobOptions{}, false},
+		{"!*/", GlobOptions{}, false},
+		{"x/", GlobOptions{TrailingSlash: true}, false},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, tc.Opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if dirOnly := g.DirOnly(); dirOnly != tc.DirOnly {
+				t.Fatalf("expected %v, got %v", tc.DirOnly, dirOnly)
+			}
+		})
+	}
+}
+
+func TestGlobMatchEntry(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.go":     {},
+		"src/main.go": {},
+	}
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tcases := []struct {
+		Pattern  string
+		Expected []string
+	}{
+		{"*", []string{"main.go", "src"}},
+		{"*/", []string{"src"}},
+		{"*.go", []string{"main.go"}},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			g := MustCompileGlob(tc.Pattern)
+			set := MustCompileGlobSet([]string{tc.Pattern})
+			var actual []string
+			for _, entry := range entries {
+				if set.MatchEntry(entry) != g.MatchEntry(entry) {
+					t.Fatalf("Glob and GlobSet disagree on %q", entry.Name())
+				}
+				if g.MatchEntry(entry) {
+					actual = append(actual, entry.Name())
+				}
+			}
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestGlobCleanPath(t *testing.T) {
+	tcases := []struct {
+		Path, Clean string
+	}{
+		{"", ""},
+		{".", "."},
+		{"./", "."},
+		{"a", "a"},
+		{"a/", "a/"},
+		{"/", "/"},
+		{"./dir/file", "dir/file"},
+		{"dir//file", "dir/file"},
+		{"dir/./file", "dir/file"},
+		{"dir/.", "dir"},
+		{"dir/./", "dir/"},
+		{"//abs///x", "/abs/x"},
+		{"/./x", "/x"},
+		{"a/../b", "a/../b"},
+		{".hidden/.x", ".hidden/.x"},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.Path, func(t *testing.T) {
+			if actual := cleanGlobPath(tc.Path, '/'); actual != tc.Clean {
+				t.Fatalf("expected %q, got %q", tc.Clean, actual)
+			}
+		})
+	}
+	if actual := cleanGlobPath(`.\dir\\x/`, '\\'); actual != "dir/x/" {
+		t.Fatalf("expected %q, got %q", "dir/x/", actual)
+	}
+
+	g, err := CompileGlobOptions("dir/*", GlobOptions{CleanPath: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"dir/file", "./dir/file", "dir//file", "./dir/./file"} {
+		if !g.Match(path) || !g.MatchBytes([]byte(path)) {
+			t.Errorf("expected %q to match %q", path, g)
+		}
+	}
+	if MustCompileGlob("dir/*").Match("./dir/file") {
+		t.Errorf("expected paths to only be cleaned with the CleanPath option")
+	}
+
+	set := NewGlobSet([]*Glob{g, MustCompileGlob("*.go")})
+	if !set.Match("./dir/file") || !set.Match("main.go") || set.Match("./main.go") {
+		t.Errorf("unexpected GlobSet matches with CleanPath")
+	}
+}
+
+func TestGlobPeriod(t *testing.T) {
+	tcases := []struct {
+		Pattern, File string
+		Match         bool
+	}{
+		{"*.log", "a.log", true},
+		{"*.log", ".hidden.log", false},
+		{".*.log", ".hidden.log", true},
+		{`\.*.log`, ".hidden.log", true},
+		{"?hidden", ".hidden", false},
+		{"[.]hidden", ".hidden", false},
+		{"*", "a.b", true},
+		{"x/*", "x/.y", false},
+		{"x/.*", "x/.y", true},
+		{"**/*.go", "a/b/c.go", true},
+		{"**/*.go", ".git/c.go", false},
+		{"**/*.go", "a/.b/c.go", false},
+		{"**/.b/*.go", "a/.b/c.go", true},
+		{"a/**", "a/.b", false},
+		{"{.,}x", ".x", true},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.Pattern+" "+tc.File, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, GlobOptions{Period: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if match := g.Match(tc.File); match != tc.Match {
+				t.Fatalf("expected %v, got %v", tc.Match, match)
+			}
+			if match := g.MatchBytes([]byte(tc.File)); match != tc.Match {
+				t.Fatalf("expected MatchBytes to return %v", tc.Match)
+			}
+		})
+	}
+
+	g, err := CompileGlobOptions("*", GlobOptions{Period: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := GlobOverlap(g, MustCompileGlob(".*")); ok {
+		t.Fatalf("expected no overlap between %q and %q", g, ".*")
+	}
+	set := NewGlobSet([]*Glob{g, MustCompileGlob("*.md")})
+	if !set.Match("a") || !set.Match(".a.md") || set.Match(".a") {
+		t.Fatalf("unexpected GlobSet matches with Period")
+	}
+}
+
+func TestGlobCrossSeparators(t *testing.T) {
+	tcases := []struct {
+		Pattern, Data string
+		Match         bool
+	}{
+		{"github.com/*", "github.com/a/b", true},
+		{"*/b", "a/x/b", true},
+		{"a?b", "a/b", true},
+		{"a[!x]b", "a/b", true},
+		{"a/**", "a/b/c", true},
+		{"a/*/b", "a/b", false},
+		{"a/*/b", "a//b", true},
+		{"*.go", "dir/main.go", true},
+		{"{x,y}/*", "y/z/w", true},
+		{"a*", "b/a", false},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.Pattern+" "+tc.Data, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, GlobOptions{CrossSeparators: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if match := g.Match(tc.Data); match != tc.Match {
+				t.Fatalf("expected %v, got %v", tc.Match, match)
+			}
+			if match := g.Regexp().MatchString(tc.Data); match != tc.Match {
+				t.Fatalf("expected Regexp to agree with Match")
+			}
+		})
+	}
+}
+
+func TestGlobMatchAny(t *testing.T) {
+	g := MustCompileGlob("*.go")
+	set := MustCompileGlobSet([]string{"*.go"})
+	tcases := []struct {
+		Data  []string
+		Match bool
+	}{
+		{nil, false},
+		{[]string{"a.md"}, false},
+		{[]string{"a.md", "main.go"}, true},
+		{[]string{"main.go", "cmd/x.go"}, true},
+		{[]string{"cmd/x.go"}, false},
+	}
+	for _, tc := range tcases {
+		if match := g.MatchAny(tc.Data); match != tc.Match {
+			t.Errorf("Glob.MatchAny(%q): expected %v, got %v", tc.Data, tc.Match, match)
+		}
+		if match := set.MatchAny(tc.Data); match != tc.Match {
+			t.Errorf("GlobSet.MatchAny(%q): expected %v, got %v", tc.Data, tc.Match, match)
+		}
+	}
+}
+
+func TestGlobFilter(t *testing.T) {
+	g := MustCompileGlob("*.go")
+	set := MustCompileGlobSet([]string{"*.go", "*.md"})
+	data := []string{"main.go", "README.md", "cmd/x.go", "util.go", "Makefile"}
+
+	matched, unmatched := g.Filter(data)
+	if expected := []string{"main.go", "util.go"}; !reflect.DeepEqual(matched, expected) {
+		t.Errorf("Glob.Filter: expected matched %q, got %q", expected, matched)
+	}
+	if expected := []string{"README.md", "cmd/x.go", "Makefile"}; !reflect.DeepEqual(unmatched, expected) {
+		t.Errorf("Glob.Filter: expected unmatched %q, got %q", expected, unmatched)
+	}
+
+	matched, unmatched = set.Filter(data)
+	if expected := []string{"main.go", "README.md", "util.go"}; !reflect.DeepEqual(matched, expected) {
+		t.Errorf("GlobSet.Filter: expected matched %q, got %q", expected, matched)
+	}
+	if expected := []string{"cmd/x.go", "Makefile"}; !reflect.DeepEqual(unmatched, expected) {
+		t.Errorf("GlobSet.Filter: expected unmatched %q, got %q", expected, unmatched)
+	}
+
+	if matched, unmatched := g.Filter(nil); matched != nil || unmatched != nil {
+		t.Errorf("expected nil partitions, got %q and %q", matched, unmatched)
+	}
+}
+
+func TestGlobTrailingSlash(t *testing.T) {
+	tcases := []struct {
+		Pattern, Data string
+		Match         bool
+	}{
+		{"dir", "dir", true},
+		{"dir", "dir/", true},
+		{"dir", "dir//", false},
+		{"dir/", "dir", true},
+		{"dir/", "dir/", true},
+		{"*", "dir/", true},
+		{"src/*", "src/cmd/", true},
+		{"src/*/", "src/cmd", true},
+		{"dir", "di", false},
+		{"dir", "dir/x", false},
+		{"", "/", true},
+		{"/", "", true},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.Pattern+" "+tc.Data, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, GlobOptions{TrailingSlash: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if match := g.Match(tc.Data); match != tc.Match {
+				t.Fatalf("expected %v, got %v", tc.Match, match)
+			}
+			set := NewGlobSet([]*Glob{g})
+			if match := set.Match(tc.Data); match != tc.Match {
+				t.Fatalf("expected GlobSet to return %v", tc.Match)
+			}
+		})
+	}
+	if MustCompileGlob("dir").Match("dir/") {
+		t.Fatalf("expected trailing slashes to matter without TrailingSlash")
+	}
+}
+
+func TestGlobMatchBase(t *testing.T) {
+	tcases := []struct {
+		Pattern, Data string
+		Match         bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "cmd/tool/main.go", true},
+		{"*.go", "cmd/tool.go/main.c", false},
+		{"main.go", "cmd/main.go", true},
+		{"main.go", "cmd/xmain.go", false},
+		{"{main,util}.go", "cmd/util.go", true},
+		{"*/", "cmd/tool/", true},
+		{"*/", "cmd/tool/main.go", false},
+		{"tool", "cmd/tool/", false},
+		{"cmd/*.go", "cmd/main.go", true},
+		{"cmd/*.go", "src/cmd/main.go", false},
+		{"{cmd/*,x}.go", "src/x.go", false},
+		{"**", "cmd/main.go", true},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.Pattern+" "+tc.Data, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, GlobOptions{MatchBase: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if match := g.Match(tc.Data); match != tc.Match {
+				t.Fatalf("expected %v, got %v", tc.Match, match)
+			}
+			if match := g.MatchBytes([]byte(tc.Data)); match != tc.Match {
+				t.Fatalf("expected MatchBytes to return %v", tc.Match)
+			}
+			set := NewGlobSet([]*Glob{g})
+			if match := set.Match(tc.Data); match != tc.Match {
+				t.Fatalf("expected GlobSet to return %v", tc.Match)
+			}
+		})
+	}
+	if g := MustCompileGlob("*.go"); g.Match("cmd/main.go") {
+		t.Fatalf("expected %q not to match the final component without MatchBase", g)
+	}
+}
+
+func TestGlobAnchorAnywhere(t *testing.T) {
+	tcases := []struct {
+		Pattern, Data string
+		Match         bool
+	}{
+		{"*.o", "main.o", true},
+		{"*.o", "src/lib/main.o", true},
+		{"*.o", "src/lib/main.c", false},
+		{"build/", "build/", true},
+		{"build/", "src/build/", true},
+		{"build/", "src/build", false},
+		{"/*.o", "main.o", true},
+		{"/*.o", "src/main.o", false},
+		{"/*.o", "/main.o", false},
+		{"src/*.o", "src/main.o", true},
+		{"src/*.o", "lib/src/main.o", false},
+		{"/src/*.o", "src/main.o", true},
+		{"!*.o", "src/main.o", true},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.Pattern+" "+tc.Data, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, GlobOptions{AnchorAnywhere: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if match := g.Match(tc.Data); match != tc.Match {
+				t.Fatalf("expected %v, got %v", tc.Match, match)
+			}
+		})
+	}
+
+	for pattern, prefix := range map[string]string{"build/": "", "/src/*.o": "src/", "src/x/*": "src/x/"} {
+		g, err := CompileGlobOptions(pattern, GlobOptions{AnchorAnywhere: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g.Prefix() != prefix {
+			t.Errorf("expected prefix %q for %q, got %q", prefix, pattern, g.Prefix())
+		}
+	}
+	if g, _ := CompileGlobOptions("/main.go", GlobOptions{AnchorAnywhere: true}); !g.IsLiteral() {
+		t.Errorf("expected %q to be literal", g)
+	}
+}
+
+func TestGlobSeparator(t *testing.T) {
+	tcases := []struct {
+		Pattern   string
+		Separator rune
+		Input     string
+		Match     bool
+		Captures  []string
+	}{
+		{"a.*", '.', "a.b", true, []string{"b"}},
+		{"a.*", '.', "a.b.c", false, nil},
+		{"a.**", '.', "a.b.c", true, []string{"b.c"}},
+		{"**.c", '.', "a.b.c", true, []string{"a.b"}},
+		{"**.c", '.', "c", true, []string{""}},
+		{"a.?", '.', "a..", false, nil},
+		{"a/*", '.', "a/b/c", true, []string{"b/c"}},
+		{"a/*", '.', "a/b.c", false, nil},
+		{"*:/bin", ':', "/usr/bin:/bin", true, []string{"/usr/bin"}},
+		{"*", ':', "/usr/bin:/bin", false, nil},
+		{"[!x]", '.', ".", true, []string{"."}},
+		{"[!x]", '.', "/", true, []string{"/"}},
+		{"a.*", '/', "a.b/c", false, nil},
+	}
+
+	for _, tc := range tcases {
+		t.Run(fmt.Sprintf("%s/%c/%s", tc.Pattern, tc.Separator, tc.Input), func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, GlobOptions{Separator: tc.Separator})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if match := g.Match(tc.Input); match != tc.Match {
+				t.Fatalf("expected match %v, got %v", tc.Match, match)
+			}
+			if captures, _ := g.Capture(tc.Input); !reflect.DeepEqual(captures, tc.Captures) {
+				t.Fatalf("expected captures %q, got %q", tc.Captures, captures)
+			}
+			re := g.Regexp()
+			if match := re.MatchString(tc.Input); match != tc.Match {
+				t.Fatalf("expected %s to match %v, got %v", re, tc.Match, match)
+			}
+		})
+	}
+
+	if g, err := CompileGlobOptions("a.b.c*", GlobOptions{Separator: '.'}); err != nil || g.Prefix() != "a.b." {
+		t.Fatalf("expected prefix %q, got %q, %v", "a.b.", g.Prefix(), err)
+	}
+	if g, err := CompileGlobOptions("a.b/c", GlobOptions{Separator: '.'}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if lit, ok := g.Literal(); !ok || lit != "a.b/c" {
+		t.Fatalf("expected literal %q, got %q", "a.b/c", lit)
+	}
+	for _, sep := range []rune{'*', '\\', ' ', 'é', '{'} {
+		if _, err := CompileGlobOptions("a", GlobOptions{Separator: sep}); !errors.Is(err, ErrInvalidSeparator) {
+			t.Errorf("%q: expected %v, got %v", sep, ErrInvalidSeparator, err)
+		}
+	}
+	_, err := CompileGlobOptions("a.[b", GlobOptions{Separator: '.'})
+	var gerr *GlobError
+	if !errors.As(err, &gerr) || gerr.Pattern != "a.[b" || gerr.Index != 2 {
+		t.Fatalf("expected error in %q at index 2, got %v", "a.[b", err)
+	}
+}
+
+func TestGlobMatchPrefix(t *testing.T) {
+	tcases := []struct {
+		Pattern, Data string
+		Match         bool
+	}{
+		{"src", "src/main.go", true},
+		{"src", "lib/main.go", false},
+		{"", "anything", true},
+		{"{src,lib}/*.d", "lib/main.d/file", true},
+		{"{src,lib}/*.d", "lib/main.go", false},
+		{"src/*", "src/", true},
+		{"src/*", "src", false},
+		{"**/test", "a/b/test/data", true},
+		{"*.go", "main.go", true},
+		{"a?c", "abcd", false},
+		{"a?c", "abc/d", true},
+		{"dir/", "dir", false},
+		{"src", "srcfoo/x", false},
+		{"src/*.d", "src/main.d/file", true},
+		{"src/*.d", "src/main.dx/file", false},
+		{"src/", "src/x", true},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.Pattern+" "+tc.Data, func(t *testing.T) {
+			if match := MustCompileGlob(tc.Pattern).MatchPrefix(tc.Data); match != tc.Match {
+				t.Fatalf("expected %v, got %v", tc.Match, match)
+			}
+		})
+	}
+
+	trailing := []struct {
+		Pattern, Data string
+		Match         bool
+	}{
+		{"dir/", "dir", true},
+		{"dir/", "dir/x", true},
+		{"dir", "dir/", true},
+		{"dir/", "di", false},
+		{"dir", "dirx", false},
+	}
+	base := []struct {
+		Pattern, Data string
+		Match         bool
+	}{
+		{"*.go", "src/main.go/x", true},
+		{"*.go", "main.go", true},
+		{"*.go", "src/main.c/x", false},
+		{"*/", "src/main.go", true},
+		{"x", "src/xy", false},
+		{"x", "src/x/y", true},
+	}
+	for _, tc := range base {
+		t.Run(tc.Pattern+" "+tc.Data+" MatchBase", func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, GlobOptions{MatchBase: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if match := g.MatchPrefix(tc.Data); match != tc.Match {
+				t.Fatalf("expected %v, got %v", tc.Match, match)
+			}
+		})
+	}
+	for _, tc := range trailing {
+		t.Run(tc.Pattern+" "+tc.Data+" TrailingSlash", func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, GlobOptions{TrailingSlash: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if match := g.MatchPrefix(tc.Data); match != tc.Match {
+				t.Fatalf("expected %v, got %v", tc.Match, match)
+			}
+		})
+	}
+}
+
+func TestGlobUnanchored(t *testing.T) {
+	compile := func(pattern string) *Glob {
+		g, err := CompileGlobOptions(pattern, GlobOptions{Unanchored: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return g
+	}
+
+	tcases := []struct {
+		Pattern, Data string
+		Match         bool
+	}{
+		{"b?d", "abcde", true},
+		{"b?d", "abde", false},
+		{"vendor/", "src/vendor/x.go", true},
+		{"/test/*.go", "pkg/test/a.go", true},
+		{"/test/*.go", "pkg/tests/a.go", false},
+		{"", "", true},
+		{"x", "", false},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.Pattern+" "+tc.Data, func(t *testing.T) {
+			if match := compile(tc.Pattern).Match(tc.Data); match != tc.Match {
+				t.Fatalf("expected %v, got %v", tc.Match, match)
+			}
+		})
+	}
+
+	g := compile("src/main.go")
+	if g.Prefix() != "" {
+		t.Fatalf("expected no prefix for unanchored glob, got %q", g.Prefix())
+	}
+	if g.IsLiteral() {
+		t.Fatalf("expected unanchored glob to not be literal")
+	}
+	set := NewGlobSet([]*Glob{g, MustCompileGlob("*.txt")})
+	if !set.Match("x/src/main.go") || !set.Match("a.txt") || set.Match("x/a.txt") {
+		t.Fatalf("unexpected GlobSet matches with unanchored glob")
+	}
+}
+
+func TestGlobMatchPath(t *testing.T) {
+	g := MustCompileGlob("src/**/*.go")
+	set := MustCompileGlobSet([]string{"src/**/*.go"})
+	for _, path := range []string{"src/main.go", "src/a/b/main.go"} {
+		native := filepath.FromSlash(path)
+		if !g.MatchPath(native) {
+			t.Errorf("expected %q to match %q", native, g)
+		}
+		if !set.MatchPath(native) {
+			t.Errorf("expected %q to match the set", native)
+		}
+	}
+
+	tcases := []struct {
+		Path  string
+		Match bool
+	}{
+		{`src\main.go`, true},
+		{`src\a/b\main.go`, true},
+		{`src/a\b.go`, true},
+		{`lib\main.go`, false},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.Path, func(t *testing.T) {
+			if match := g.prog.match(tc.Path, matchMode{sep: '\\'}); match != tc.Match {
+				t.Fatalf("expected %v, got %v", tc.Match, match)
+			}
+		})
+	}
+}
+
+func TestGlobText(t *testing.T) {
+	type config struct {
+		Include *Glob
+		Exclude []*Glob
+		Main    Glob
+	}
+
+	in := `{"Include":"src/**","Exclude":["*_test.go","{a,b}/*"],"Main":"main.go"}`
+
+	var cfg config
+	if err := json.Unmarshal([]byte(in), &cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.Include.Match("src/x/y.go") || !cfg.Exclude[1].Match("b/c") || !cfg.Main.Match("main.go") {
+		t.Fatalf("unmarshaled globs do not match as expected")
+	}
+
+	out, err := json.Marshal(&cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != in {
+		t.Fatalf("expected %s, got %s", in, out)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Include":"[a"}`), &cfg); err == nil {
+		t.Fatalf("expected error for invalid pattern")
+	}
+}
+
+func BenchmarkGlobMatchBytes(b *testing.B) {
+	g := MustCompileGlob("**/*.go")
+	line := []byte("src/cmd/tool/main.go")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.MatchBytes(line)
+	}
+}
+
+func TestGlobSkipHidden(t *testing.T) {
+	tcases := []struct {
+		Pattern string
+		Input   string
+		Match   bool
+	}{
+		{"**/*.go", "src/main.go", true},
+		{"**/*.go", ".hidden.go", true},
+		{"**/*.go", "src/.hidden.go", true},
+		{"**/*.go", ".git/x.go", false},
+		{"**/*.go", "src/.cache/x.go", false},
+		{"**/.git/*", "src/.git/config", true},
+		{"**/.git/*", ".x/.git/config", false},
+		{".github/**", ".github/ci.yml", true},
+		{".github/**", ".github/.hidden", false},
+		{"src/**", "src/a/b", true},
+		{"src/**", "src/.a/b", false},
+		{"src/**", "src/.a", false},
+		{"src/*/x", "src/.a/x", true},
+		{"src/**/x", "src/a.b/x", true},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern+" "+tc.Input, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, GlobOptions{SkipHidden: true})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if match := g.Match(tc.Input); match != tc.Match {
+				t.Fatalf("expected match %v, got %v", tc.Match, match)
+			}
+		})
+	}
+
+	t.Run("CrossSeparators", func(t *testing.T) {
+		g, err := CompileGlobOptions("a**", GlobOptions{SkipHidden: true, CrossSeparators: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !g.Match("a/.b") {
+			t.Fatal("expected stars to match leading periods without separators")
+		}
+	})
+
+	t.Run("Walk", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"a.go":         {},
+			"src/b.go":     {},
+			".git/c.go":    {},
+			"src/.d/e.go":  {},
+			".github/f.go": {},
+		}
+		g, err := CompileGlobOptions("**/*.go", GlobOptions{SkipHidden: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		matches, err := g.Walk(fsys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if expected := []string{"a.go", "src/b.go"}; !reflect.DeepEqual(matches, expected) {
+			t.Fatalf("expected %v, got %v", expected, matches)
+		}
+	})
+}
+
+func TestGlobMatchDirPrefix(t *testing.T) {
+	tcases := []struct {
+		Pattern string
+		Options GlobOptions
+		Dir     string
+		Match   bool
+	}{
+		{"src/*/gen/**", GlobOptions{}, "src", true},
+		{"src/*/gen/**", GlobOptions{}, "src/x", true},
+		{"src/*/gen/**", GlobOptions{}, "src/x/", true},
+		{"src/*/gen/**", GlobOptions{}, "src/x/gen", true},
+		{"src/*/gen/**", GlobOptions{}, "src/x/gen/a/b", true},
+		{"src/*/gen/**", GlobOptions{}, "src/x/y", false},
+		{"src/*/gen/**", GlobOptions{}, "docs", false},
+		{"src/*/gen/**", GlobOptions{}, "", true},
+		{"*.go", GlobOptions{}, "src", false},
+		{"**/*.go", GlobOptions{}, "a/b/c", true},
+		{"{a,b}/*", GlobOptions{}, "b", true},
+		{"{a,b}/*", GlobOptions{}, "c", false},
+		{"SRC/*", GlobOptions{CaseInsensitive: true}, "src", true},
+		{"*.go", GlobOptions{MatchBase: true}, "src", true},
+		{"!src/**", GlobOptions{}, "docs", true},
+		{"src/*", GlobOptions{CleanPath: true}, "./src", true},
+		{"a.b.*", GlobOptions{Separator: '.'}, "a.b", true},
+		{"a.b.*", GlobOptions{Separator: '.'}, "a.c", false},
+		{"a.b.*", GlobOptions{Separator: '.'}, "a", true},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern+" "+tc.Dir, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, tc.Options)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if match := g.MatchDirPrefix(tc.Dir); match != tc.Match {
+				t.Fatalf("expected %v, got %v", tc.Match, match)
+			}
+		})
+	}
+}
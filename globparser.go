@@ -0,0 +1,144 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrUnterminatedToken = errors.New("unterminated token")
+	ErrUnknownToken      = errors.New("unknown token")
+	ErrTokenRecursion    = errors.New("token expansion nested too deeply")
+)
+
+// maxTokenDepth is the maximum number of nested token expansions, which
+// guards against tokens expanding to themselves.
+const maxTokenDepth = 16
+
+// A TokenFunc expands a custom token into a glob sub-pattern. arg is the text
+// following the first ":" in the token, or the empty string if the token has
+// no argument.
+type TokenFunc func(arg string) (pattern string, err error)
+
+// GlobParser compiles glob patterns extended with custom tokens.
+//
+// Custom tokens are denoted with %{name} or %{name:arg}. When the parser
+// encounters a token, it calls the TokenFunc registered under its name, and
+// parses the returned sub-pattern in place of the token. For instance, with:
+//
+//	var p GlobParser
+//	p.RegisterToken("semver", func(string) (string, error) {
+//		return "[0-9]*.[0-9]*.[0-9]*", nil
+//	})
+//
+// the pattern "pkg-%{semver}.tar.gz" matches "pkg-1.2.3.tar.gz".
+//
+// A literal "%{" can be written as "%\{", with the closing brace escaped as
+// well, as in "%\{name\}". Sub-patterns cannot be negated, and a "!" at their
+// start is treated as a literal "!".
+//
+// The zero value is a parser with no custom tokens, compiling patterns like
+// CompileGlob.
+type GlobParser struct {
+	// Options are the options patterns are compiled with, as with
+	// CompileGlobOptions. They apply to the sub-patterns of tokens as well,
+	// which are written with the same separator as the patterns.
+	Options GlobOptions
+
+	tokens map[string]TokenFunc
+}
+
+// RegisterToken registers fn as the expansion of the %{name} token. It
+// replaces any previous registration under the same name.
+func (gp *GlobParser) RegisterToken(name string, fn TokenFunc) {
+	if gp.tokens == nil {
+		gp.tokens = make(map[string]TokenFunc)
+	}
+	gp.tokens[name] = fn
+}
+
+// Compile compiles the specified pattern into a Glob object, expanding the
+// custom tokens registered on the parser.
+//
+// Note that the resulting Glob does not remember its parser: unmarshaling it
+// from text compiles the pattern with CompileGlobOptions, and the options of
+// the parser.
+func (gp *GlobParser) Compile(pattern string) (*Glob, error) {
+	return compileGlob(pattern, gp.Options, gp.tokens)
+}
+
+// MustCompile is like Compile, but panics if the function returned an error.
+func (gp *GlobParser) MustCompile(pattern string) *Glob {
+	glob, err := gp.Compile(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return glob
+}
+
+func parseToken(p *globParser) parseFunc {
+	start := p.index - p.width
+	p.next() // '{'
+
+	end := strings.IndexByte(p.in[p.index:], '}')
+	if end == -1 {
+		p.err = &GlobError{Pattern: p.in, Index: start, Err: ErrUnterminatedToken}
+		return nil
+	}
+	name, arg := p.in[p.index:p.index+end], ""
+	if i := strings.IndexByte(name, ':'); i != -1 {
+		name, arg = name[:i], name[i+1:]
+	}
+	p.index += end + len(`}`)
+
+	fn, ok := p.tokens[name]
+	if !ok {
+		p.err = &GlobError{Pattern: p.in, Index: start, Err: ErrUnknownToken}
+		return nil
+	}
+	if p.depth >= maxTokenDepth {
+		p.err = &GlobError{Pattern: p.in, Index: start, Err: ErrTokenRecursion}
+		return nil
+	}
+	expansion, err := fn(arg)
+	if err != nil {
+		p.err = &GlobError{Pattern: p.in, Index: start, Err: err}
+		return nil
+	}
+
+	if p.strictUTF8 && !utf8.ValidString(expansion) {
+		p.err = &GlobError{Pattern: p.in, Index: start, Err: ErrInvalidUTF8}
+		return nil
+	}
+	if p.sep != 0 {
+		expansion = swapRunes(expansion, p.sep, '/')
+	}
+
+	sub := globParser{
+		in:         expansion,
+		flags:      p.flags,
+		fnmatch:    p.fnmatch,
+		tokens:     p.tokens,
+		depth:      p.depth + 1,
+		rawBytes:   p.rawBytes,
+		strictUTF8: p.strictUTF8,
+		sep:        p.sep,
+	}
+	sub.run()
+	if sub.err != nil {
+		p.err = &GlobError{Pattern: p.in, Index: start, Err: sub.err}
+		return nil
+	}
+	// Wildcards of expansions are not reported by Glob.Capture, since they
+	// do not appear in the pattern.
+	p.seq = append(p.seq, uncaptured(sub.seq)...)
+	p.literals += sub.literals
+	p.wildcards += sub.wildcards
+	return parseMain
+}
@@ -0,0 +1,136 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGlobParser(t *testing.T) {
+	var p GlobParser
+	p.RegisterToken("semver", func(string) (string, error) {
+		return "[0-9]*.[0-9]*.[0-9]*", nil
+	})
+	p.RegisterToken("ext", func(arg string) (string, error) {
+		return "{" + strings.Replace(arg, "|", ",", -1) + "}", nil
+	})
+	p.RegisterToken("neg", func(string) (string, error) {
+		return "!x", nil
+	})
+	p.RegisterToken("loop", func(string) (string, error) {
+		return "%{loop}", nil
+	})
+	p.RegisterToken("fail", func(string) (string, error) {
+		return "", errors.New("failure")
+	})
+
+	t.Run("Match", func(t *testing.T) {
+		tcases := []struct {
+			Pattern, File string
+			Match         bool
+		}{
+			{"pkg-%{semver}.tar.gz", "pkg-1.2.3.tar.gz", true},
+			{"pkg-%{semver}.tar.gz", "pkg-1.2.tar.gz", false},
+			{"*.%{ext:c|h}", "file.c", true},
+			{"*.%{ext:c|h}", "file.h", true},
+			{"*.%{ext:c|h}", "file.go", false},
+			{"%{neg}", "!x", true},
+			{`%\{semver\}`, "%{semver}", true},
+			{"100%", "100%", true},
+		}
+
+		for _, tc := range tcases {
+			t.Run(tc.Pattern, func(t *testing.T) {
+				g, err := p.Compile(tc.Pattern)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if ok := g.Match(tc.File); ok != tc.Match {
+					if tc.Match {
+						t.Fatalf("expected %q to match %q, but it didn't", tc.File, tc.Pattern)
+					} else {
+						t.Fatalf("expected %q to not match %q, but it did", tc.File, tc.Pattern)
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tcases := []struct {
+			Pattern string
+			Err     error
+		}{
+			{"%{semver", ErrUnterminatedToken},
+			{"%{unknown}", ErrUnknownToken},
+			{"%{loop}", ErrTokenRecursion},
+		}
+
+		for _, tc := range tcases {
+			t.Run(tc.Pattern, func(t *testing.T) {
+				_, err := p.Compile(tc.Pattern)
+				if !errors.Is(err, tc.Err) {
+					t.Fatalf("expected error %v, got %v", tc.Err, err)
+				}
+			})
+		}
+
+		if _, err := p.Compile("%{fail}"); err == nil {
+			t.Fatalf("expected error from failing token")
+		}
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		var p GlobParser
+		p.RegisterToken("key", func(string) (string, error) {
+			return "*.Name", nil
+		})
+		p.RegisterToken("byte", func(string) (string, error) {
+			return "\xff", nil
+		})
+		tcases := []struct {
+			Pattern, File string
+			Opts          GlobOptions
+			Match         bool
+		}{
+			{"app.%{key}", "app.db.name", GlobOptions{Separator: '.', CaseInsensitive: true}, true},
+			{"app.%{key}", "app.db.x.name", GlobOptions{Separator: '.', CaseInsensitive: true}, false},
+			{"app.%{key}", "app.db.name", GlobOptions{Separator: '.'}, false},
+			{"x%{byte}", "x\xff", GlobOptions{InvalidUTF8: UTF8Bytes}, true},
+			{"x%{byte}", "x\xfe", GlobOptions{InvalidUTF8: UTF8Bytes}, false},
+		}
+
+		for _, tc := range tcases {
+			t.Run(tc.Pattern, func(t *testing.T) {
+				p.Options = tc.Opts
+				g, err := p.Compile(tc.Pattern)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if ok := g.Match(tc.File); ok != tc.Match {
+					t.Fatalf("expected Match(%q) to be %v, got %v", tc.File, tc.Match, ok)
+				}
+			})
+		}
+
+		p.Options = GlobOptions{InvalidUTF8: UTF8Strict}
+		if _, err := p.Compile("x%{byte}"); !errors.Is(err, ErrInvalidUTF8) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidUTF8, err)
+		}
+	})
+
+	t.Run("NoTokens", func(t *testing.T) {
+		ok, err := GlobMatch("%{a,b}", "%b")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !ok {
+			t.Fatalf(`expected "%%" to be literal without a parser`)
+		}
+	})
+}
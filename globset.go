@@ -0,0 +1,377 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// GlobSet represents a set of compiled glob patterns, matching any string
+// that at least one of its patterns matches.
+//
+// The patterns of a set are combined into a single automaton, which scans
+// strings once, whatever the number of patterns. This makes matching against
+// a GlobSet much faster than matching against each of its patterns in turn,
+// even for sets of thousands of patterns.
+//
+// Negated patterns, starting with "!", are ordered exclusions rather than
+// patterns matching the strings they do not match: a string matched by a
+// negated pattern is excluded from the strings matched by the patterns
+// before it, and re-included by a later pattern matching it. In other
+// words, the last pattern matching a string decides whether the set matches
+// it, as in gitignore(5) files: {"src/**", "!**/*_test.go", "src/x_test.go"}
+// matches "src/x.go" and "src/x_test.go", but not "src/y_test.go". A set
+// made of negated patterns only matches nothing.
+type GlobSet struct {
+	globs  []*Glob
+	groups []globGroup
+
+	// ordered is set if some globs are negated, and the last glob matching
+	// decides.
+	ordered bool
+
+	// subtracted are the sets whose matches are excluded, see Subtract.
+	subtracted []*GlobSet
+
+	// stats holds the counters of each glob, if recorded, see WithStats.
+	stats []patternCounters
+
+	// metrics receives the counters of the set, if set when it was created.
+	metrics Metrics
+}
+
+// globGroup combines the globs of a set that share the same input options.
+// indices maps the indices of the patterns matched by the dfa to the indices
+// of the globs in the set.
+type globGroup struct {
+	input   inputOptions
+	dfa     *dfa
+	indices []int
+}
+
+// CompileGlobSet compiles the specified patterns into a GlobSet.
+//
+// See the documentation of the Glob type for more details on the supported syntax.
+//
+// If some patterns are invalid, the returned error reports all of them, as
+// with CompileGlobs.
+func CompileGlobSet(patterns []string) (*GlobSet, error) {
+	globs, err := CompileGlobs(patterns)
+	if err != nil {
+		return nil, err
+	}
+	return NewGlobSet(globs), nil
+}
+
+// MustCompileGlobSet is like CompileGlobSet, but panics if the function returned an error.
+func MustCompileGlobSet(patterns []string) *GlobSet {
+	set, err := CompileGlobSet(patterns)
+	if err != nil {
+		panic(err)
+	}
+	return set
+}
+
+// LoadPatterns reads patterns from r, one per line, and compiles them into a
+// GlobSet. Leading and trailing white space is ignored, and so are blank
+// lines and lines starting with "#". A pattern starting with "#" can be
+// escaped with a backslash. Negated patterns, starting with "!", exclude the
+// paths matched by the lines before them, unless a later line includes them
+// again, as described in GlobSet.
+//
+// Unlike ParseFilterFile, LoadPatterns does not accept "+ " and "- " rules:
+// in a GlobSet, the last matching pattern wins, and patterns are anchored
+// globs, while in a Filter, the first matching rule wins, and patterns are
+// anchored the way rsync anchors them.
+func LoadPatterns(r io.Reader) (*GlobSet, error) {
+	var globs []*Glob
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		pattern := strings.TrimSpace(scanner.Text())
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+		g, err := CompileGlob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		globs = append(globs, g)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return NewGlobSet(globs), nil
+}
+
+// NewGlobSet returns a GlobSet made of already compiled globs, which allows
+// combining globs compiled with different options.
+func NewGlobSet(globs []*Glob) *GlobSet {
+	set := &GlobSet{globs: append([]*Glob(nil), globs...), metrics: currentMetrics()}
+	var inputs []inputOptions
+	grouped := make(map[inputOptions][]int)
+	for i, g := range globs {
+		set.ordered = set.ordered || g.negated
+		input := g.opts.input()
+		if _, ok := grouped[input]; !ok {
+			inputs = append(inputs, input)
+		}
+		grouped[input] = append(grouped[input], i)
+	}
+	for _, input := range inputs {
+		indices := grouped[input]
+		group := make([]*Glob, len(indices))
+		for i, j := range indices {
+			group[i] = globs[j]
+		}
+		set.groups = append(set.groups, globGroup{input: input, dfa: newDFA(combine(group)), indices: indices})
+	}
+	return set
+}
+
+// combine returns a program matching the strings any of globs matches. The
+// match instruction of each glob holds its index in globs. The globs must
+// decode invalid UTF-8 the same way.
+func combine(globs []*Glob) *program {
+	prog := new(program)
+	if len(globs) > 0 {
+		prog.utf8 = globs[0].opts.InvalidUTF8
+	}
+	for i, g := range globs {
+		split := -1
+		if i < len(globs)-1 {
+			split = prog.emit(inst{op: instSplit, x: len(prog.insts) + 1})
+		}
+		g.compileTo(prog)
+		prog.insts[len(prog.insts)-1].x = i
+		if split != -1 {
+			prog.insts[split].y = len(prog.insts)
+		}
+	}
+	return prog
+}
+
+// Globs returns the globs of the set, in the order they were specified. The
+// globs of subtracted sets are not included.
+func (s *GlobSet) Globs() []*Glob {
+	return append([]*Glob(nil), s.globs...)
+}
+
+// Subtract returns a set matching the strings s matches but t does not, as
+// in "everything under src but the tests". The returned set can be used
+// wherever s can, and subtracted from further: s.Subtract(t).Subtract(u)
+// matches the strings s matches but neither t nor u do. Its Globs are those
+// of s, and MatchIndex and MatchIndices report the indices of those. For the
+// strings a subtracted set excludes, MatchIndex returns -1, as
+// MatchIndices returns nil.
+func (s *GlobSet) Subtract(t *GlobSet) *GlobSet {
+	return &GlobSet{
+		globs:      s.globs,
+		groups:     s.groups,
+		ordered:    s.ordered,
+		subtracted: append(s.subtracted[:len(s.subtracted):len(s.subtracted)], t),
+		stats:      s.stats,
+		metrics:    s.metrics,
+	}
+}
+
+// Match returns whether data matches at least one pattern of the set, or,
+// if the set has negated patterns, whether the last pattern matching data is
+// not negated.
+func (s *GlobSet) Match(data string) bool {
+	return s.match(data, '/')
+}
+
+func (s *GlobSet) match(data string, sep rune) bool {
+	match := s.matches(data, sep)
+	countMatch(s.metrics, match)
+	return match
+}
+
+// matches is like match, without reporting metrics.
+func (s *GlobSet) matches(data string, sep rune) bool {
+	if s.ordered || s.stats != nil {
+		_, match := s.decide(data, sep)
+		return match
+	}
+	for _, group := range s.groups {
+		if group.dfa.last(group.input.prepare(data, sep), group.input.mode(sep, false)) != -1 {
+			return !s.subtractedMatch(data, sep)
+		}
+	}
+	return false
+}
+
+// decide returns the index of the pattern deciding whether the set matches
+// data, as reported by MatchIndex, and whether it does.
+func (s *GlobSet) decide(data string, sep rune) (int, bool) {
+	var last int
+	if s.stats != nil {
+		last = s.lastEach(data, sep)
+	} else {
+		last = s.last(data, sep)
+	}
+	switch {
+	case last == -1 || s.globs[last].negated:
+		return last, false
+	case s.subtractedMatch(data, sep):
+		return -1, false
+	}
+	return last, true
+}
+
+// last returns the index of the last glob of the set matching data, or -1
+// if none does, regardless of negations and subtracted sets.
+func (s *GlobSet) last(data string, sep rune) int {
+	last := -1
+	for _, group := range s.groups {
+		if i := group.dfa.last(group.input.prepare(data, sep), group.input.mode(sep, false)); i != -1 {
+			last = max(last, group.indices[i])
+		}
+	}
+	return last
+}
+
+// lastEach is like last, but tests the globs one by one, and records
+// whether each matched.
+func (s *GlobSet) lastEach(data string, sep rune) int {
+	last := -1
+	for i, g := range s.globs {
+		m := g.matches(data, sep, false)
+		s.stats[i].record(m)
+		if m {
+			last = i
+		}
+	}
+	return last
+}
+
+// subtractedMatch returns whether data matches one of the subtracted sets.
+func (s *GlobSet) subtractedMatch(data string, sep rune) bool {
+	for _, t := range s.subtracted {
+		if t.matches(data, sep) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchIndex returns the index of the pattern of the set deciding whether
+// data matches, which is the last pattern matching data, or -1 if none does.
+// Together with Globs, it allows reporting which pattern matched, or which
+// negated pattern excluded data: the set matches data if the pattern is not
+// negated. If a subtracted set excludes data, MatchIndex returns -1.
+func (s *GlobSet) MatchIndex(data string) int {
+	i, match := s.decide(data, '/')
+	countMatch(s.metrics, match)
+	return i
+}
+
+// matchIndex is like MatchIndex, but returns -1 if the set does not match
+// data.
+func (s *GlobSet) matchIndex(data string) int {
+	i, match := s.decide(data, '/')
+	countMatch(s.metrics, match)
+	if !match {
+		return -1
+	}
+	return i
+}
+
+// MatchIndices returns the indices of all the patterns of the set matching
+// data, in increasing order, if the set matches data. Negated patterns are
+// never reported.
+func (s *GlobSet) MatchIndices(data string) []int {
+	if _, match := s.decide(data, '/'); !match {
+		countMatch(s.metrics, false)
+		return nil
+	}
+	countMatch(s.metrics, true)
+	var indices []int
+	for _, group := range s.groups {
+		for _, i := range group.dfa.all(group.input.prepare(data, '/'), group.input.mode('/', false)) {
+			if j := group.indices[i]; !s.globs[j].negated {
+				indices = append(indices, j)
+			}
+		}
+	}
+	slices.Sort(indices)
+	return indices
+}
+
+// MatchDirPrefix returns whether paths under the directory dir may match at
+// least one pattern of the set, as described in Glob.MatchDirPrefix. Negated
+// patterns only exclude paths, and are not taken into account.
+func (s *GlobSet) MatchDirPrefix(dir string) bool {
+	for _, g := range s.globs {
+		if !g.negated && g.MatchDirPrefix(dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchAny returns whether at least one of the strings of data matches at
+// least one pattern of the set. It stops at the first match.
+func (s *GlobSet) MatchAny(data []string) bool {
+	for _, d := range data {
+		if s.Match(d) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter partitions data into the strings that match at least one pattern of
+// the set and the ones that do not, both in their original order.
+func (s *GlobSet) Filter(data []string) (matched, unmatched []string) {
+	for _, d := range data {
+		if s.Match(d) {
+			matched = append(matched, d)
+		} else {
+			unmatched = append(unmatched, d)
+		}
+	}
+	return matched, unmatched
+}
+
+// MatchPath returns whether path matches at least one pattern of the set.
+// See Glob.MatchPath for details.
+func (s *GlobSet) MatchPath(path string) bool {
+	return s.match(path, filepath.Separator)
+}
+
+// MatchInfo returns whether the specified FileInfo matches at least one
+// pattern of the set. See Glob.MatchInfo for details.
+func (s *GlobSet) MatchInfo(info os.FileInfo) bool {
+	match := s.Match(info.Name())
+	if info.IsDir() {
+		match = match || s.Match(info.Name()+"/")
+	}
+	return match
+}
+
+// MatchEntry returns whether the specified directory entry matches at least
+// one pattern of the set. See Glob.MatchEntry for details.
+func (s *GlobSet) MatchEntry(entry fs.DirEntry) bool {
+	match := s.Match(entry.Name())
+	if entry.IsDir() {
+		match = match || s.Match(entry.Name()+"/")
+	}
+	return match
+}
+
+// MatchName returns whether the specified Namer matches at least one pattern
+// of the set. It is equivalent to Match(namer.Name()).
+func (s *GlobSet) MatchName(namer Namer) bool {
+	return s.Match(namer.Name())
+}
@@ -0,0 +1,259 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestGlobSet(t *testing.T) {
+	set := MustCompileGlobSet([]string{"*.go", "docs/**", "Makefile", "[!a-z]*.md"})
+
+	tcases := []struct {
+		File  string
+		Match bool
+	}{
+		{"main.go", true},
+		{"cmd/main.go", false},
+		{"docs/", true},
+		{"docs/index.html", true},
+		{"Makefile", true},
+		{"README.md", true},
+		{"notes.md", false},
+		{"", false},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.File, func(t *testing.T) {
+			if ok := set.Match(tc.File); ok != tc.Match {
+				if tc.Match {
+					t.Fatalf("expected %q to match the set, but it didn't", tc.File)
+				} else {
+					t.Fatalf("expected %q to not match the set, but it did", tc.File)
+				}
+			}
+		})
+	}
+
+	t.Run("Options", func(t *testing.T) {
+		g, err := CompileGlobOptions("*.GO", GlobOptions{CaseInsensitive: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		set := NewGlobSet([]*Glob{g, MustCompileGlob("README")})
+		if !set.Match("main.go") || set.Match("readme") || !set.Match("README") {
+			t.Fatalf("expected each glob of the set to keep its options")
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		set := MustCompileGlobSet(nil)
+		if set.Match("") || set.Match("file") {
+			t.Fatalf("expected an empty set to match nothing")
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if _, err := CompileGlobSet([]string{"*.go", "[a"}); err == nil {
+			t.Fatalf("expected error for invalid pattern")
+		}
+	})
+}
+
+func TestGlobSetMatchIndex(t *testing.T) {
+	set := MustCompileGlobSet([]string{"*.go", "docs/**", "*_test.go", "docs/*.md"})
+
+	tcases := []struct {
+		File    string
+		Index   int
+		Indices []int
+	}{
+		{"main.go", 0, []int{0}},
+		{"main_test.go", 2, []int{0, 2}},
+		{"docs/x", 1, []int{1}},
+		{"docs/x.md", 3, []int{1, 3}},
+		{"README", -1, nil},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.File, func(t *testing.T) {
+			if index := set.MatchIndex(tc.File); index != tc.Index {
+				t.Fatalf("expected index %d, got %d", tc.Index, index)
+			}
+			if indices := set.MatchIndices(tc.File); !reflect.DeepEqual(indices, tc.Indices) {
+				t.Fatalf("expected indices %v, got %v", tc.Indices, indices)
+			}
+		})
+	}
+
+	set = MustCompileGlobSet([]string{"*.go"})
+	if index := set.MatchIndex("a.md"); index != -1 {
+		t.Fatalf("expected -1, got %d", index)
+	}
+	if indices := set.MatchIndices("a.md"); indices != nil {
+		t.Fatalf("expected no indices, got %v", indices)
+	}
+}
+
+func TestGlobSetSubtract(t *testing.T) {
+	src := MustCompileGlobSet([]string{"src/**", "*.go"})
+	tests := MustCompileGlobSet([]string{"**/*_test.go"})
+	testdata := MustCompileGlobSet([]string{"**/testdata/**"})
+	keep := MustCompileGlobSet([]string{"src/testdata/keep/**"})
+
+	tcases := []struct {
+		Set      *GlobSet
+		Input    string
+		Expected int
+	}{
+		{src.Subtract(tests), "src/a.go", 0},
+		{src.Subtract(tests), "src/a_test.go", -1},
+		{src.Subtract(tests), "main.go", 1},
+		{src.Subtract(tests), "main_test.go", -1},
+		{src.Subtract(tests), "README.md", -1},
+		{src.Subtract(tests).Subtract(testdata), "src/testdata/x", -1},
+		{src.Subtract(tests).Subtract(testdata), "src/x", 0},
+		{src.Subtract(testdata.Subtract(keep)), "src/testdata/x", -1},
+		{src.Subtract(testdata.Subtract(keep)), "src/testdata/keep/x", 0},
+		{src.Subtract(src), "src/x", -1},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Input, func(t *testing.T) {
+			if actual := tc.Set.MatchIndex(tc.Input); actual != tc.Expected {
+				t.Fatalf("expected %d, got %d", tc.Expected, actual)
+			}
+			if actual := tc.Set.Match(tc.Input); actual != (tc.Expected != -1) {
+				t.Fatalf("expected match %v, got %v", tc.Expected != -1, actual)
+			}
+		})
+	}
+
+	// Subtracting does not alter the original sets.
+	if !src.Match("src/a_test.go") || !testdata.Match("src/testdata/keep/x") {
+		t.Fatal("Subtract altered the original sets")
+	}
+}
+
+func TestGlobSetNegated(t *testing.T) {
+	set := MustCompileGlobSet([]string{"src/**", "!**/*_test.go", "src/keep_test.go", "!src/gen/**"})
+
+	tcases := []struct {
+		Input    string
+		Expected int
+		Match    bool
+	}{
+		{"src/a.go", 0, true},
+		{"src/a_test.go", 1, false},
+		{"src/keep_test.go", 2, true},
+		{"src/gen/a.go", 3, false},
+		{"src/gen/keep_test.go", 3, false},
+		{"main.go", -1, false},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Input, func(t *testing.T) {
+			if actual := set.MatchIndex(tc.Input); actual != tc.Expected {
+				t.Fatalf("expected %d, got %d", tc.Expected, actual)
+			}
+			if actual := set.Match(tc.Input); actual != tc.Match {
+				t.Fatalf("expected match %v, got %v", tc.Match, actual)
+			}
+		})
+	}
+
+	if indices := set.MatchIndices("src/keep_test.go"); !reflect.DeepEqual(indices, []int{0, 2}) {
+		t.Fatalf("expected indices [0 2], got %v", indices)
+	}
+	if indices := set.MatchIndices("src/gen/a.go"); indices != nil {
+		t.Fatalf("expected no indices, got %v", indices)
+	}
+
+	t.Run("NegatedOnly", func(t *testing.T) {
+		set := MustCompileGlobSet([]string{"!*.go"})
+		if set.Match("main.go") || set.Match("README") {
+			t.Fatal("expected a set of negated patterns to match nothing")
+		}
+	})
+
+	t.Run("Walk", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"src/a.go":           {},
+			"src/a_test.go":      {},
+			"src/gen/b.go":       {},
+			"src/keep_test.go":   {},
+			"docs/index_test.go": {},
+		}
+		matches, err := set.Walk(fsys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := []string{"src", "src/a.go", "src/gen", "src/keep_test.go"}
+		if !reflect.DeepEqual(matches, expected) {
+			t.Fatalf("expected %v, got %v", expected, matches)
+		}
+	})
+}
+
+func TestGlobSetMatchDirPrefix(t *testing.T) {
+	set := MustCompileGlobSet([]string{"src/**/*.go", "docs/*.md", "!build/**"})
+
+	for dir, expected := range map[string]bool{
+		"src":       true,
+		"src/a/b":   true,
+		"docs":      true,
+		"docs/api":  false,
+		"build":     false,
+		"README.md": false,
+	} {
+		if match := set.MatchDirPrefix(dir); match != expected {
+			t.Errorf("%s: expected %v, got %v", dir, expected, match)
+		}
+	}
+}
+
+func TestLoadPatterns(t *testing.T) {
+	input := strings.Join([]string{
+		"# Sources",
+		"src/**",
+		"",
+		"  !**/*_test.go  ",
+		"src/keep_test.go",
+		"- dash",
+		`\#hash`,
+		"*.md\r",
+	}, "\n")
+	set, err := LoadPatterns(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for path, expected := range map[string]bool{
+		"src/a.go":         true,
+		"src/a_test.go":    false,
+		"src/keep_test.go": true,
+		"- dash":           true,
+		"#hash":            true,
+		"README.md":        true,
+		"# Sources":        false,
+		"main.go":          false,
+	} {
+		if match := set.Match(path); match != expected {
+			t.Errorf("%s: expected %v, got %v", path, expected, match)
+		}
+	}
+	if n := len(set.Globs()); n != 6 {
+		t.Errorf("expected 6 patterns, got %d", n)
+	}
+
+	_, err = LoadPatterns(strings.NewReader("*.go\n\n![a\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 3: ") || !errors.Is(err, ErrUnterminatedClass) {
+		t.Fatalf("expected error on line 3, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+// patternVars is a VariableMap whose values are glob patterns, see
+// PatternVars.
+type patternVars struct {
+	VariableMap
+}
+
+// PatternVars returns a VariableMap with the same variables as vars, whose
+// values CompileGlobSubst substitutes as patterns rather than literally: with
+// ARCH=*64, "bin/${ARCH}/*" matches "bin/x86_64/ls".
+func PatternVars(vars VariableMap) VariableMap {
+	return patternVars{vars}
+}
+
+// CompileGlobSubst substitutes the variables of pattern with Substitute, and
+// compiles the result into a Glob object.
+//
+// The values of the variables are substituted literally: their special
+// characters are escaped with QuoteGlobMeta, such that with NAME=a*, the
+// pattern "src/${NAME}/**" only matches under the "src/a*" directory. So are
+// the results of regexp replacements, which are derived from the values.
+// Default and alternate values, as in ${NAME:-*}, are part of the pattern,
+// and are substituted as is. To substitute the values of variables as
+// patterns, wrap vars with PatternVars.
+func CompileGlobSubst(pattern string, vars VariableMap) (*Glob, error) {
+	return CompileGlobSubstOptions(pattern, vars, GlobOptions{})
+}
+
+// CompileGlobSubstOptions is like CompileGlobSubst, but compiles the pattern
+// with the specified options.
+func CompileGlobSubstOptions(pattern string, vars VariableMap, opts GlobOptions) (*Glob, error) {
+	quote := QuoteGlobMeta
+	if _, ok := vars.(patternVars); ok {
+		quote = nil
+	}
+	pattern, err := substitute(pattern, vars, quote)
+	if err != nil {
+		return nil, err
+	}
+	return CompileGlobOptions(pattern, opts)
+}
@@ -0,0 +1,67 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"testing"
+)
+
+func TestCompileGlobSubst(t *testing.T) {
+	vars := SimpleVariableMap{
+		"ARCH": "x86_64",
+		"NAME": "a*",
+		"ALT":  "{b,c}",
+		"PAIR": "a*:b",
+	}
+
+	tcases := []struct {
+		Pattern string
+		Vars    VariableMap
+		Data    string
+		Match   bool
+	}{
+		{"bin/${ARCH}/*", vars, "bin/x86_64/ls", true},
+		{"bin/${ARCH}/*", vars, "bin/arm64/ls", false},
+		{"src/${NAME}/**", vars, "src/a*/x", true},
+		{"src/${NAME}/**", vars, "src/ab/x", false},
+		{"${ALT}.go", vars, "{b,c}.go", true},
+		{"${ALT}.go", vars, "b.go", false},
+		{"${MISSING:-*}.go", vars, "x.go", true},
+		{"${ARCH:+*}.go", vars, "x.go", true},
+		{`${PAIR/^([^:]*):.*/\1/}`, vars, "a*", true},
+		{`${PAIR/^([^:]*):.*/\1/}`, vars, "ab", false},
+		{"src/${NAME}/**", PatternVars(vars), "src/ab/x", true},
+		{"${ALT}.go", PatternVars(vars), "b.go", true},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern+" "+tc.Data, func(t *testing.T) {
+			g, err := CompileGlobSubst(tc.Pattern, tc.Vars)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if match := g.Match(tc.Data); match != tc.Match {
+				t.Fatalf("expected %v, got %v", tc.Match, match)
+			}
+		})
+	}
+
+	t.Run("Options", func(t *testing.T) {
+		g, err := CompileGlobSubstOptions("${ARCH}/*", vars, GlobOptions{CaseInsensitive: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !g.Match("X86_64/ls") {
+			t.Fatal("expected the options to apply")
+		}
+	})
+
+	t.Run("Undefined", func(t *testing.T) {
+		if _, err := CompileGlobSubst("${MISSING}/*", vars); err == nil {
+			t.Fatal("expected error for undefined variable")
+		}
+	})
+}
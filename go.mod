@@ -1,3 +1,3 @@
 module barney.ci/shutil
 
-go 1.12
+go 1.23
@@ -0,0 +1,243 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type ignoreRule struct {
+	glob      *Glob
+	reinclude bool
+}
+
+// IgnoreSet represents an ordered list of ignore rules, evaluated with the
+// semantics of gitignore(5): the last rule matching a path decides whether
+// it is ignored.
+//
+// Each rule is a glob pattern, optionally prefixed by "!". A path matching a
+// plain rule is ignored, while a path matching a "!"-prefixed rule is
+// re-included, unless a later rule ignores it again. A rule starting with a
+// literal "!" can be written with a backslash, as in "\!important".
+//
+// Rules are only evaluated against the path itself, and not against its
+// parent directories: "build/**" rather than "build" is needed to ignore the
+// contents of the build directory. Callers walking a tree usually skip the
+// ignored directories altogether, in which case, as with git, files cannot be
+// re-included under an ignored directory.
+type IgnoreSet struct {
+	rules []ignoreRule
+
+	// globs combines the globs of the rules, to find the last one matching
+	// with a single scan.
+	globs *GlobSet
+
+	// stats holds the counters of each rule, if recorded, see WithStats.
+	stats []patternCounters
+}
+
+// CompileIgnoreSet compiles the specified ordered list of rules into an
+// IgnoreSet.
+func CompileIgnoreSet(rules []string) (*IgnoreSet, error) {
+	compiled := make([]ignoreRule, 0, len(rules))
+	for _, rule := range rules {
+		r, err := compileIgnoreRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, r)
+	}
+	return newIgnoreSet(compiled), nil
+}
+
+// compileIgnoreRule compiles a rule of an IgnoreSet.
+func compileIgnoreRule(rule string) (ignoreRule, error) {
+	reinclude := strings.HasPrefix(rule, "!")
+	if reinclude {
+		rule = rule[1:]
+	}
+	g, err := CompileGlob(rule)
+	if err != nil {
+		return ignoreRule{}, err
+	}
+	return ignoreRule{glob: g, reinclude: reinclude}, nil
+}
+
+// newIgnoreSet returns the IgnoreSet made of the compiled rules.
+func newIgnoreSet(rules []ignoreRule) *IgnoreSet {
+	globs := make([]*Glob, len(rules))
+	for i, rule := range rules {
+		globs[i] = rule.glob
+	}
+	set := &IgnoreSet{rules: rules}
+	set.globs = NewGlobSet(globs)
+	return set
+}
+
+// MustCompileIgnoreSet is like CompileIgnoreSet, but panics if the function returned an error.
+func MustCompileIgnoreSet(rules []string) *IgnoreSet {
+	set, err := CompileIgnoreSet(rules)
+	if err != nil {
+		panic(err)
+	}
+	return set
+}
+
+// Match returns whether path is ignored by the set. A path ending with "/"
+// denotes a directory, and is also matched without its trailing slash, so
+// that a rule like "build" ignores the "build/" directory.
+func (s *IgnoreSet) Match(path string) bool {
+	ignored, _ := s.decide(path)
+	return ignored
+}
+
+// decide returns whether path is ignored by the set, and whether a rule of
+// the set matched path at all.
+func (s *IgnoreSet) decide(path string) (ignored, decided bool) {
+	dir := strings.TrimSuffix(path, "/")
+	if s.stats == nil {
+		last := s.globs.last(path, '/')
+		if dir != path {
+			last = max(last, s.globs.last(dir, '/'))
+		}
+		countMatch(s.globs.metrics, last != -1)
+		if last == -1 {
+			return false, false
+		}
+		return !s.rules[last].reinclude, true
+	}
+	for i := len(s.rules) - 1; i >= 0; i-- {
+		rule := s.rules[i]
+		match := rule.glob.Match(path) || dir != path && rule.glob.Match(dir)
+		if s.stats != nil {
+			s.stats[i].record(match)
+		}
+		if match {
+			return !rule.reinclude, true
+		}
+	}
+	return false, false
+}
+
+// ParseIgnoreFile parses rules in the gitignore(5) format from r, and
+// compiles them into an IgnoreSet:
+//
+//  - Blank lines and lines starting with "#" are ignored.
+//  - Trailing spaces are ignored, unless escaped with a backslash.
+//  - A leading "!" re-includes the paths matched by the rest of the line.
+//  - A trailing "/" restricts the rule to directories and their contents.
+//  - A rule containing a "/" other than a trailing one is anchored to the
+//    root of the tree, while other rules match at any depth.
+//  - A rule matching a directory also matches its contents.
+//  - "*", "?" and bracket expressions do not match "/", and "**" only has a
+//    special meaning as a full path component. Curly braces match themselves.
+//
+// Paths are matched as described in IgnoreSet.Match, and are relative to the
+// directory containing the ignore file.
+func ParseIgnoreFile(r io.Reader) (*IgnoreSet, error) {
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		rule, ok := translateIgnoreRule(scanner.Text())
+		if !ok {
+			continue
+		}
+		compiled, err := compileIgnoreRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rules = append(rules, compiled)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return newIgnoreSet(rules), nil
+}
+
+// translateIgnoreRule translates a line of a gitignore file into a rule for
+// CompileIgnoreSet. It returns false if the line contains no rule.
+func translateIgnoreRule(line string) (string, bool) {
+	line = strings.TrimSuffix(line, "\r")
+	if strings.HasPrefix(line, "#") {
+		return "", false
+	}
+
+	// Strip unescaped trailing spaces.
+	end := len(line)
+	for end > 0 && line[end-1] == ' ' {
+		escapes := 0
+		for i := end - 2; i >= 0 && line[i] == '\\'; i-- {
+			escapes++
+		}
+		if escapes%2 == 1 {
+			break
+		}
+		end--
+	}
+	line = line[:end]
+	if line == "" {
+		return "", false
+	}
+
+	var reinclude bool
+	if strings.HasPrefix(line, "!") {
+		reinclude, line = true, line[1:]
+	}
+	dirOnly := strings.HasSuffix(line, "/")
+	line = strings.TrimSuffix(line, "/")
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	if line == "" {
+		return "", false
+	}
+
+	var b strings.Builder
+	if reinclude {
+		b.WriteRune('!')
+	}
+	if !anchored {
+		b.WriteString("**/")
+	}
+	for i, component := range strings.Split(line, "/") {
+		if i > 0 {
+			b.WriteRune('/')
+		}
+		if component == "**" {
+			b.WriteString(component)
+			continue
+		}
+		for j := 0; j < len(component); j++ {
+			switch c := component[j]; c {
+			case '\\':
+				b.WriteByte(c)
+				if j+1 < len(component) {
+					j++
+					b.WriteByte(component[j])
+				}
+			case '{', '}', ',':
+				b.WriteRune('\\')
+				b.WriteByte(c)
+			case '*':
+				// "**" is only special as a full component.
+				for j+1 < len(component) && component[j+1] == '*' {
+					j++
+				}
+				b.WriteByte(c)
+			default:
+				b.WriteByte(c)
+			}
+		}
+	}
+	if dirOnly {
+		b.WriteString("/**")
+	} else {
+		b.WriteString("{,/**}")
+	}
+	return b.String(), true
+}
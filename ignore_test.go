@@ -0,0 +1,137 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIgnoreSet(t *testing.T) {
+	set := MustCompileIgnoreSet([]string{
+		"**/*.log",
+		"!**/important.log",
+		"build",
+		"build/**",
+		"!build/keep",
+		"tmp/*",
+		"!tmp/keep/",
+		"tmp/keep/*.bak",
+		`\!bang`,
+	})
+
+	tcases := []struct {
+		Path    string
+		Ignored bool
+	}{
+		{"main.go", false},
+		{"debug.log", true},
+		{"logs/debug.log", true},
+		{"important.log", false},
+		{"logs/important.log", false},
+		{"build", true},
+		{"build/", true},
+		{"build/keep", false},
+		{"build/out/bin", true},
+		{"tmp/x", true},
+		{"tmp/keep/", false},
+		{"tmp/keep/x", false},
+		{"tmp/keep/x.bak", true},
+		{"!bang", true},
+		{"bang", false},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Path, func(t *testing.T) {
+			if ok := set.Match(tc.Path); ok != tc.Ignored {
+				if tc.Ignored {
+					t.Fatalf("expected %q to be ignored, but it wasn't", tc.Path)
+				} else {
+					t.Fatalf("expected %q to not be ignored, but it was", tc.Path)
+				}
+			}
+		})
+	}
+
+	if _, err := CompileIgnoreSet([]string{"[a"}); err == nil {
+		t.Fatalf("expected error for invalid rule")
+	}
+}
+
+func TestParseIgnoreFile(t *testing.T) {
+	set, err := ParseIgnoreFile(strings.NewReader(strings.Join([]string{
+		"# build artifacts",
+		"*.o",
+		"/bin/",
+		"build",
+		"!build/README",
+		"",
+		"docs/**/*.tmp",
+		"a**b",
+		`\#hash`,
+		`\!bang`,
+		`trailing\ `,
+		"spaces   ",
+		"{braces}",
+		"logs/",
+		"!logs/keep.log",
+	}, "\n")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tcases := []struct {
+		Path    string
+		Ignored bool
+	}{
+		{"main.c", false},
+		{"main.o", true},
+		{"src/lib/main.o", true},
+		{"bin/", true},
+		{"bin/tool", true},
+		{"bin", false},
+		{"src/bin/tool", false},
+		{"build", true},
+		{"build/", true},
+		{"build/out", true},
+		{"src/build/out", true},
+		{"build/README", false},
+		{"docs/x.tmp", true},
+		{"docs/a/b/x.tmp", true},
+		{"x.tmp", false},
+		{"axxb", true},
+		{"ax/xb", false},
+		{"#hash", true},
+		{"!bang", true},
+		{"trailing ", true},
+		{"trailing", false},
+		{"spaces", true},
+		{"braces", false},
+		{"{braces}", true},
+		{"logs/", true},
+		{"logs/debug.log", true},
+		{"logs/keep.log", false},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Path, func(t *testing.T) {
+			if ok := set.Match(tc.Path); ok != tc.Ignored {
+				if tc.Ignored {
+					t.Fatalf("expected %q to be ignored, but it wasn't", tc.Path)
+				} else {
+					t.Fatalf("expected %q to not be ignored, but it was", tc.Path)
+				}
+			}
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseIgnoreFile(strings.NewReader("*.o\n[a\n"))
+		if err == nil || !strings.HasPrefix(err.Error(), "line 2: ") {
+			t.Fatalf("expected error on line 2, got %v", err)
+		}
+	})
+}
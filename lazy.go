@@ -0,0 +1,75 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"sync"
+)
+
+// LazyGlob is a glob pattern compiled the first time it is used, rather than
+// when it is declared. It is safe for concurrent use.
+//
+// This allows declaring globs as package-level variables without paying for
+// their compilation at initialization, and without MustCompileGlob panicking
+// on an invalid pattern:
+//
+//	var goFiles = shutil.NewLazyGlob("*.go")
+//
+// Compilation errors are instead returned by each call to a method of the
+// LazyGlob.
+type LazyGlob struct {
+	pattern string
+	opts    GlobOptions
+
+	once sync.Once
+	glob *Glob
+	err  error
+}
+
+// NewLazyGlob returns a LazyGlob compiling the specified pattern on first use.
+func NewLazyGlob(pattern string) *LazyGlob {
+	return &LazyGlob{pattern: pattern}
+}
+
+// NewLazyGlobOptions is like NewLazyGlob, but compiles the pattern with the
+// specified options.
+func NewLazyGlobOptions(pattern string, opts GlobOptions) *LazyGlob {
+	return &LazyGlob{pattern: pattern, opts: opts}
+}
+
+// Glob compiles the pattern if it was not already, and returns the compiled
+// glob, or the error compiling it.
+func (l *LazyGlob) Glob() (*Glob, error) {
+	l.once.Do(func() {
+		l.glob, l.err = CompileGlobOptions(l.pattern, l.opts)
+	})
+	return l.glob, l.err
+}
+
+// Match compiles the pattern if it was not already, and then returns
+// Glob.Match(data).
+func (l *LazyGlob) Match(data string) (bool, error) {
+	g, err := l.Glob()
+	if err != nil {
+		return false, err
+	}
+	return g.Match(data), nil
+}
+
+// MatchPath compiles the pattern if it was not already, and then returns
+// Glob.MatchPath(path).
+func (l *LazyGlob) MatchPath(path string) (bool, error) {
+	g, err := l.Glob()
+	if err != nil {
+		return false, err
+	}
+	return g.MatchPath(path), nil
+}
+
+// String returns the pattern of the glob, without compiling it.
+func (l *LazyGlob) String() string {
+	return l.pattern
+}
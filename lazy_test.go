@@ -0,0 +1,58 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestLazyGlob(t *testing.T) {
+	l := NewLazyGlob("*.go")
+	if l.glob != nil {
+		t.Fatal("glob compiled before first use")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, err := l.Match("main.go"); err != nil || !ok {
+				t.Errorf("Match(%q) = %v, %v, expected true, nil", "main.go", ok, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if ok, err := l.Match("main.c"); err != nil || ok {
+		t.Errorf("Match(%q) = %v, %v, expected false, nil", "main.c", ok, err)
+	}
+	if g, _ := l.Glob(); g != l.glob {
+		t.Error("glob was compiled more than once")
+	}
+
+	fold := NewLazyGlobOptions("*.GO", GlobOptions{CaseInsensitive: true})
+	if ok, err := fold.Match("main.go"); err != nil || !ok {
+		t.Errorf("Match(%q) = %v, %v, expected true, nil", "main.go", ok, err)
+	}
+}
+
+func TestLazyGlobError(t *testing.T) {
+	l := NewLazyGlob("{a,b")
+	if l.String() != "{a,b" {
+		t.Errorf("String() = %q, expected %q", l.String(), "{a,b")
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := l.Match("a"); !errors.Is(err, ErrUnterminatedBrace) {
+			t.Errorf("expected ErrUnterminatedBrace, got %v", err)
+		}
+		if _, err := l.MatchPath("a"); !errors.Is(err, ErrUnterminatedBrace) {
+			t.Errorf("expected ErrUnterminatedBrace, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,503 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Patterns are parsed into a sequence of nodes, which is then compiled into a
+// program for a small non-deterministic automaton. The automaton is simulated
+// in lockstep over the input, as described in
+// https://swtch.com/~rsc/regexp/regexp1.html. This keeps matching linear in
+// the length of the input, whatever the pattern, without allocating.
+
+type nodeOp uint8
+
+const (
+	// nodeRune matches the rune r.
+	nodeRune nodeOp = iota
+
+	// nodeClass matches a rune of class.
+	nodeClass
+
+	// nodeStar matches zero or more runes of class.
+	nodeStar
+
+	// nodeAlt matches any of the sequences of alts.
+	nodeAlt
+)
+
+// node is an element of a parsed pattern. capture is true if the node
+// stems from a wildcard or brace group of the pattern, whose match is
+// reported by Glob.Capture.
+type node struct {
+	op      nodeOp
+	r       rune
+	class   *charClass
+	alts    [][]node
+	capture bool
+}
+
+// optional returns a node matching either nothing, or the sequence nodes.
+func optional(nodes ...node) node {
+	return node{op: nodeAlt, alts: [][]node{nil, nodes}}
+}
+
+// uncaptured returns a copy of nodes without capture groups.
+func uncaptured(nodes []node) []node {
+	out := make([]node, len(nodes))
+	for i, n := range nodes {
+		n.capture = false
+		if n.alts != nil {
+			alts := make([][]node, len(n.alts))
+			for j, alt := range n.alts {
+				alts[j] = uncaptured(alt)
+			}
+			n.alts = alts
+		}
+		out[i] = n
+	}
+	return out
+}
+
+type instOp uint8
+
+const (
+	// instRune consumes the rune r.
+	instRune instOp = iota
+
+	// instClass consumes a rune of class.
+	instClass
+
+	// instSplit continues at both x and y.
+	instSplit
+
+	// instJmp continues at x.
+	instJmp
+
+	// instMatch reports a match if the input is exhausted. In the programs
+	// of sets, x is the index of the pattern matched.
+	instMatch
+
+	// instSave records the current position in capture slot x, and
+	// continues at the next instruction.
+	instSave
+)
+
+// inst is an instruction of a program.
+type inst struct {
+	op instOp
+	instMode
+	r     rune
+	class *charClass
+	x, y  int
+}
+
+// instMode holds the options of the glob an instruction was compiled from.
+type instMode struct {
+
+	// fold makes the instruction match case-insensitively.
+	fold bool
+
+	// period prevents classes from matching a leading period in a path
+	// component.
+	period bool
+
+	// skipHidden sets period for the stars of "**", which match any rune.
+	// It only affects compilation.
+	skipHidden bool
+}
+
+// matches returns whether the rune-consuming instruction accepts r. If fold
+// is true, r is matched case-insensitively even if the instruction is not.
+// leading is true if r starts a path component.
+func (i *inst) matches(r rune, fold, leading bool) bool {
+	fold = fold || i.fold
+	switch i.op {
+	case instRune:
+		return r == i.r || fold && equalFold(r, i.r)
+	case instClass:
+		if leading && r == '.' && i.period {
+			return false
+		}
+		if !fold {
+			return i.class.matches(r)
+		}
+		// Like regexp, fold the ranges of the class before negating it.
+		in := i.class.contains(r)
+		for f := unicode.SimpleFold(r); !in && f != r; f = unicode.SimpleFold(f) {
+			in = i.class.contains(f)
+		}
+		return in != i.class.negated
+	}
+	return false
+}
+
+// equalFold returns whether a and b are equal under simple Unicode case
+// folding.
+func equalFold(a, b rune) bool {
+	for f := unicode.SimpleFold(a); f != a; f = unicode.SimpleFold(f) {
+		if f == b {
+			return true
+		}
+	}
+	return false
+}
+
+// program is a compiled pattern. Execution starts at the first instruction.
+type program struct {
+	insts []inst
+
+	// ncap is the number of capture groups of the program, each using two
+	// slots for its start and end positions.
+	ncap int
+
+	// shortcut, if set, matches the same strings as the instructions
+	// without running the automaton.
+	shortcut *shortcut
+
+	// utf8 sets how invalid UTF-8 is decoded from the strings matched.
+	utf8 UTF8Mode
+}
+
+// shortcut matches strings made of a literal, optionally preceded or
+// followed by any number of runes of a class, like "*.go" or "src/*". Most
+// patterns take this form.
+type shortcut struct {
+	lit string
+
+	// star is the class of the runes preceding or following lit, if any.
+	star *charClass
+
+	// leading is true if the runes of star precede lit.
+	leading bool
+}
+
+// newShortcut returns a shortcut matching the same strings as nodes, or nil
+// if there is none.
+func newShortcut(nodes []node) *shortcut {
+	var sc shortcut
+	if len(nodes) > 0 && nodes[0].op == nodeStar {
+		sc.star, sc.leading = nodes[0].class, true
+		nodes = nodes[1:]
+	} else if len(nodes) > 0 && nodes[len(nodes)-1].op == nodeStar {
+		sc.star = nodes[len(nodes)-1].class
+		nodes = nodes[:len(nodes)-1]
+	}
+	var lit strings.Builder
+	for _, n := range nodes {
+		if n.op != nodeRune || n.r == utf8.RuneError {
+			// Invalid UTF-8 decodes to utf8.RuneError, which would have to
+			// match any invalid byte.
+			return nil
+		}
+		writeRune(&lit, n.r)
+	}
+	sc.lit = lit.String()
+	return &sc
+}
+
+func (sc *shortcut) match(s string) bool {
+	var rest string
+	switch {
+	case sc.star == nil:
+		return s == sc.lit
+	case sc.leading && strings.HasSuffix(s, sc.lit):
+		rest = s[:len(s)-len(sc.lit)]
+	case !sc.leading && strings.HasPrefix(s, sc.lit):
+		rest = s[len(sc.lit):]
+	default:
+		return false
+	}
+	for _, r := range rest {
+		if !sc.star.matches(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// rejects returns whether s must not match because it is not valid UTF-8.
+func (prog *program) rejects(s string) bool {
+	return prog.utf8 == UTF8Strict && !utf8.ValidString(s)
+}
+
+func (prog *program) emit(i inst) int {
+	prog.insts = append(prog.insts, i)
+	return len(prog.insts) - 1
+}
+
+// compile appends the instructions matching the sequence nodes to the
+// program, in the specified mode.
+func (prog *program) compile(nodes []node, mode instMode) {
+	for _, n := range nodes {
+		if n.capture {
+			slot := 2 * prog.ncap
+			prog.ncap++
+			prog.emit(inst{op: instSave, x: slot})
+			n.capture = false
+			prog.compile([]node{n}, mode)
+			prog.emit(inst{op: instSave, x: slot + 1})
+			continue
+		}
+		switch n.op {
+		case nodeRune:
+			prog.emit(inst{op: instRune, r: n.r, instMode: mode})
+		case nodeClass:
+			prog.emit(inst{op: instClass, class: n.class, instMode: mode})
+		case nodeStar:
+			starMode := mode
+			if mode.skipHidden && n.class == anyRune {
+				starMode.period = true
+			}
+			split := prog.emit(inst{op: instSplit})
+			prog.emit(inst{op: instClass, class: n.class, instMode: starMode})
+			prog.emit(inst{op: instJmp, x: split})
+			prog.insts[split].x = split + 1
+			prog.insts[split].y = len(prog.insts)
+		case nodeAlt:
+			var jmps []int
+			for i, alt := range n.alts {
+				if i == len(n.alts)-1 {
+					prog.compile(alt, mode)
+					break
+				}
+				split := prog.emit(inst{op: instSplit})
+				prog.compile(alt, mode)
+				jmps = append(jmps, prog.emit(inst{op: instJmp}))
+				prog.insts[split].x = split + 1
+				prog.insts[split].y = len(prog.insts)
+			}
+			for _, jmp := range jmps {
+				prog.insts[jmp].x = len(prog.insts)
+			}
+		}
+	}
+}
+
+// threadList is a sparse set of program counters, which can be cleared in
+// constant time.
+type threadList struct {
+	sparse []uint32
+	dense  []uint32
+}
+
+func (l *threadList) reset(n int) {
+	if cap(l.sparse) < n {
+		l.sparse = make([]uint32, n)
+		l.dense = make([]uint32, 0, n)
+	}
+	l.sparse = l.sparse[:n]
+	l.dense = l.dense[:0]
+}
+
+func (l *threadList) contains(pc int) bool {
+	i := l.sparse[pc]
+	return int(i) < len(l.dense) && l.dense[i] == uint32(pc)
+}
+
+func (l *threadList) insert(pc int) {
+	l.sparse[pc] = uint32(len(l.dense))
+	l.dense = append(l.dense, uint32(pc))
+}
+
+// machine holds the state of a program execution. If fold is true, all
+// instructions match case-insensitively. leading is true when the next rune
+// starts a path component.
+type machine struct {
+	prog          *program
+	fold, leading bool
+	clist, nlist  threadList
+}
+
+var machinePool = sync.Pool{
+	New: func() interface{} { return new(machine) },
+}
+
+func (prog *program) machine(fold bool) *machine {
+	m := machinePool.Get().(*machine)
+	m.prog = prog
+	m.fold = fold
+	m.leading = true
+	m.clist.reset(len(prog.insts))
+	m.nlist.reset(len(prog.insts))
+	m.add(&m.clist, 0)
+	return m
+}
+
+func (m *machine) release() {
+	m.prog = nil
+	machinePool.Put(m)
+}
+
+// add adds the thread at pc to l, following jumps and splits.
+func (m *machine) add(l *threadList, pc int) {
+	if l.contains(pc) {
+		return
+	}
+	l.insert(pc)
+	switch i := &m.prog.insts[pc]; i.op {
+	case instJmp:
+		m.add(l, i.x)
+	case instSave:
+		m.add(l, pc+1)
+	case instSplit:
+		m.add(l, i.x)
+		m.add(l, i.y)
+	}
+}
+
+// step advances all threads over r. It returns false if no thread survived.
+func (m *machine) step(r rune) bool {
+	m.nlist.dense = m.nlist.dense[:0]
+	for _, pc := range m.clist.dense {
+		if i := &m.prog.insts[pc]; i.matches(r, m.fold, m.leading) {
+			m.add(&m.nlist, int(pc)+1)
+		}
+	}
+	m.leading = r == '/'
+	m.clist, m.nlist = m.nlist, m.clist
+	return len(m.clist.dense) > 0
+}
+
+// matched returns whether a thread reached a match instruction.
+func (m *machine) matched() bool {
+	for _, pc := range m.clist.dense {
+		if m.prog.insts[pc].op == instMatch {
+			return true
+		}
+	}
+	return false
+}
+
+func (prog *program) matchString(s string) bool {
+	return prog.match(s, matchMode{})
+}
+
+// matchMode alters the way a program matches strings.
+type matchMode struct {
+
+	// sep is a path separator treated as if it were "/", if not zero.
+	sep rune
+
+	// fold makes all instructions match case-insensitively.
+	fold bool
+
+	// trailingSlash makes a trailing slash optional, both in the pattern and
+	// in the string matched.
+	trailingSlash bool
+}
+
+func (prog *program) match(s string, mode matchMode) bool {
+	sep := mode.sep
+	if sep == 0 {
+		sep = '/'
+	}
+	if prog.rejects(s) {
+		return false
+	}
+	if prog.shortcut != nil && !mode.fold && !mode.trailingSlash && (sep == '/' || !strings.ContainsRune(s, sep)) {
+		return prog.shortcut.match(s)
+	}
+	m := prog.machine(mode.fold)
+	defer m.release()
+	slash := false
+	for i := 0; i < len(s); {
+		r, n := rune(s[i]), 1
+		if r >= utf8.RuneSelf {
+			r, n = decodeRune(s[i:], prog.utf8)
+		}
+		if r == sep {
+			r = '/'
+		}
+		if mode.trailingSlash && r == '/' && i == len(s)-1 && m.matched() {
+			return true
+		}
+		if !m.step(r) {
+			return false
+		}
+		slash = r == '/'
+		i += n
+	}
+	if m.matched() {
+		return true
+	}
+	return mode.trailingSlash && !slash && m.step('/') && m.matched()
+}
+
+// matchPrefix returns whether a prefix of s ending at a path component
+// boundary, that is, at the end of s, before a separator or after one,
+// matches in the given mode.
+func (prog *program) matchPrefix(s string, mode matchMode) bool {
+	sep := mode.sep
+	if sep == 0 {
+		sep = '/'
+	}
+	if prog.rejects(s) {
+		return false
+	}
+	m := prog.machine(mode.fold)
+	defer m.release()
+	if m.matched() {
+		return true
+	}
+	slash := false
+	for i := 0; i < len(s); {
+		r, n := decodeRune(s[i:], prog.utf8)
+		if r == sep {
+			r = '/'
+			if m.matched() {
+				return true
+			}
+		}
+		if !m.step(r) {
+			return false
+		}
+		if r == '/' && m.matched() {
+			return true
+		}
+		slash = r == '/'
+		i += n
+	}
+	if m.matched() {
+		return true
+	}
+	return mode.trailingSlash && !slash && m.step('/') && m.matched()
+}
+
+// viable returns whether s may be the prefix of a string matching prog.
+func (prog *program) viable(s string) bool {
+	if prog.rejects(s) {
+		return false
+	}
+	m := prog.machine(false)
+	defer m.release()
+	for i := 0; i < len(s); {
+		r, n := decodeRune(s[i:], prog.utf8)
+		if !m.step(r) {
+			return false
+		}
+		i += n
+	}
+	return true
+}
+
+func (prog *program) matchBytes(b []byte) bool {
+	m := prog.machine(false)
+	defer m.release()
+	for len(b) > 0 {
+		r, n := utf8.DecodeRune(b)
+		if !m.step(r) {
+			return false
+		}
+		b = b[n:]
+	}
+	return m.matched()
+}
@@ -0,0 +1,133 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the machine pool drops machines under the race detector")
+	}
+	for _, pattern := range []string{
+		"main.go",
+		"*.go",
+		"**/*.go",
+		"{src,lib}/**/[a-z]*_test.go",
+		"!foo/*",
+	} {
+		g := MustCompileGlob(pattern)
+		path := "src/foo/bar/baz_test.go"
+		b := []byte(path)
+		g.Match(path) // warm up the machine pool
+
+		if n := testing.AllocsPerRun(100, func() { g.Match(path) }); n != 0 {
+			t.Errorf("%q: Match allocated %v times, expected none", pattern, n)
+		}
+		if n := testing.AllocsPerRun(100, func() { g.MatchBytes(b) }); n != 0 {
+			t.Errorf("%q: MatchBytes allocated %v times, expected none", pattern, n)
+		}
+	}
+}
+
+func TestMatchShortcut(t *testing.T) {
+	tcases := []struct {
+		Pattern  string
+		Shortcut bool
+	}{
+		{"", true},
+		{"main.go", true},
+		{"*.go", true},
+		{"src/*", true},
+		{"src/**", true},
+		{"**", true},
+		{`\*.go`, true},
+		{"src/*.go", false},
+		{"*/x", false},
+		{"{a,b}", false},
+		{"file?", false},
+		{"[ab]*", false},
+	}
+	inputs := []string{"", "main.go", "a/main.go", "x.go", ".go", "src", "src/", "src/a", "src/a/b", "*.go", "file1"}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			g := MustCompileGlob(tc.Pattern)
+			if (g.prog.shortcut != nil) != tc.Shortcut {
+				t.Fatalf("expected shortcut %v", tc.Shortcut)
+			}
+			slow := &program{insts: g.prog.insts}
+			for _, input := range inputs {
+				if g.Match(input) != slow.matchString(input) {
+					t.Fatalf("shortcut and automaton disagree on %q", input)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchPathological(t *testing.T) {
+	// Backtracking matchers take exponential time on these.
+	pattern := strings.Repeat("*a", 30) + "b"
+	g := MustCompileGlob(pattern)
+	if g.Match(strings.Repeat("a", 1000)) {
+		t.Fatalf("expected %q to not match", pattern)
+	}
+
+	pattern = strings.Repeat("**/", 30) + "x"
+	g = MustCompileGlob(pattern)
+	if g.Match(strings.Repeat("a/", 1000)) {
+		t.Fatalf("expected %q to not match", pattern)
+	}
+}
+
+func TestMatchNUL(t *testing.T) {
+	// "**" used to be unable to match NUL bytes.
+	g := MustCompileGlob("a/**")
+	if !g.Match("a/b\x00c/d") {
+		t.Fatalf("expected %q to match", "a/b\\x00c/d")
+	}
+}
+
+func TestMatchSetCaseFolding(t *testing.T) {
+	folded, err := CompileGlobOptions("*.TXT", GlobOptions{CaseInsensitive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := NewGlobSet([]*Glob{MustCompileGlob("*.go"), folded})
+	for name, expected := range map[string]bool{
+		"main.go":  true,
+		"main.GO":  false,
+		"a.txt":    true,
+		"a.Txt":    true,
+		"a.md":     false,
+		"dir/a.go": false,
+	} {
+		if match := set.Match(name); match != expected {
+			t.Errorf("Match(%q): expected %v, got %v", name, expected, match)
+		}
+	}
+}
+
+func BenchmarkGlobMatchShortcut(b *testing.B) {
+	g := MustCompileGlob("*.go")
+	path := "src/foo/bar/baz_test.go"
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.Match(path)
+	}
+}
+
+func BenchmarkGlobMatch(b *testing.B) {
+	g := MustCompileGlob("{src,lib}/**/[a-z]*_test.go")
+	path := "src/foo/bar/baz_test.go"
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.Match(path)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// A Matcher reports whether a path matches some criteria. Notable types that
+// implement this interface are *Glob and the matchers returned by Rooted.
+type Matcher interface {
+	Match(path string) bool
+}
+
+// MatcherFunc is an adapter to allow the use of ordinary functions as
+// Matchers.
+type MatcherFunc func(path string) bool
+
+func (fn MatcherFunc) Match(path string) bool {
+	return fn(path)
+}
+
+type rootedMatcher struct {
+	root string
+	m    Matcher
+}
+
+// Rooted returns a Matcher that converts candidate paths to a form relative
+// to root before matching them with m, which allows patterns to be written
+// relative to root while callers pass absolute paths.
+//
+// Absolute candidates are made relative to root, and relative candidates are
+// assumed to already be relative to root. Candidates that lie outside of root
+// never match. Relative paths are passed to m with forward slashes, and keep
+// their trailing slash if they had one, so that patterns like "*/" still only
+// match directories. root itself is passed to m as ".".
+//
+// A relative root is made absolute with filepath.Abs when Rooted is called,
+// such that absolute candidates are compared with the directory root
+// designated then.
+func Rooted(root string, m Matcher) Matcher {
+	if abs, err := filepath.Abs(root); err == nil {
+		root = abs
+	}
+	return &rootedMatcher{root: filepath.Clean(root), m: m}
+}
+
+func (r *rootedMatcher) Match(path string) bool {
+	rel, ok := r.rel(path)
+	if !ok {
+		return false
+	}
+	return r.m.Match(rel)
+}
+
+func (r *rootedMatcher) rel(path string) (string, bool) {
+	dir := strings.HasSuffix(path, "/") || strings.HasSuffix(path, string(os.PathSeparator))
+
+	var rel string
+	if filepath.IsAbs(path) {
+		var err error
+		if rel, err = filepath.Rel(r.root, path); err != nil {
+			return "", false
+		}
+	} else {
+		rel = filepath.Clean(path)
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+		return "", false
+	}
+
+	rel = filepath.ToSlash(rel)
+	if dir && rel != "." {
+		rel += "/"
+	}
+	return rel, true
+}
@@ -0,0 +1,61 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRooted(t *testing.T) {
+	tcases := []struct {
+		Pattern, Path string
+		Match         bool
+	}{
+		{"src/*.go", "/repo/src/main.go", true},
+		{"src/*.go", "/repo/src/../src/main.go", true},
+		{"src/*.go", "src/main.go", true},
+		{"src/*.go", "./src/main.go", true},
+		{"src/*.go", "/other/src/main.go", false},
+		{"src/*.go", "/repo/../src/main.go", false},
+		{"**", "/repo/../outside", false},
+		{"**", "../outside", false},
+		{"*/", "/repo/src/", true},
+		{"*/", "/repo/src", false},
+		{"**", "/repo", true},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern+":"+tc.Path, func(t *testing.T) {
+			m := Rooted("/repo/", MustCompileGlob(tc.Pattern))
+			if ok := m.Match(tc.Path); ok != tc.Match {
+				if tc.Match {
+					t.Fatalf("expected %q to match %q, but it didn't", tc.Path, tc.Pattern)
+				} else {
+					t.Fatalf("expected %q to not match %q, but it did", tc.Path, tc.Pattern)
+				}
+			}
+		})
+	}
+}
+
+func TestRootedRelative(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := Rooted("repo", MustCompileGlob("src/*.go"))
+	for path, match := range map[string]bool{
+		filepath.Join(wd, "repo", "src", "main.go"): true,
+		filepath.Join(wd, "src", "main.go"):         false,
+		"src/main.go":                               true,
+	} {
+		if ok := m.Match(path); ok != match {
+			t.Errorf("%s: expected %v, got %v", path, match, ok)
+		}
+	}
+}
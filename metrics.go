@@ -0,0 +1,110 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"expvar"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	ErrExpvarInUse = errors.New("expvar variable in use")
+)
+
+// Names of the counters reported to Metrics.
+const (
+	// MetricGlobsCompiled counts the glob patterns successfully compiled.
+	MetricGlobsCompiled = "globs_compiled"
+
+	// MetricPathsTested counts the candidates tested against a glob.
+	MetricPathsTested = "paths_tested"
+
+	// MetricPathsMatched counts the candidates that matched a glob.
+	MetricPathsMatched = "paths_matched"
+
+	// MetricFilesVisited counts the files and directories visited while
+	// walking a filesystem.
+	MetricFilesVisited = "files_visited"
+)
+
+// Metrics is the interface that wraps the Add method.
+//
+// Add adds delta to the counter identified by name. It is called
+// concurrently from any goroutine using this package, and must therefore be
+// safe for concurrent use and cheap.
+type Metrics interface {
+	Add(name string, delta int64)
+}
+
+type metricsHolder struct {
+	m Metrics
+}
+
+var metrics atomic.Value
+
+// SetMetrics sets the sink receiving the counters reported by this package.
+// Passing nil disables reporting, which is the default.
+//
+// Globs, sets and walks report to the sink set when they are created, which
+// keeps matching from looking it up every time. SetMetrics is therefore
+// meant to be called once, at initialization: globs compiled before keep
+// reporting to the previous sink, if any.
+func SetMetrics(m Metrics) {
+	metrics.Store(metricsHolder{m})
+}
+
+// currentMetrics returns the sink set by SetMetrics, or nil.
+func currentMetrics() Metrics {
+	h, _ := metrics.Load().(metricsHolder)
+	return h.m
+}
+
+func addMetric(name string, delta int64) {
+	if m := currentMetrics(); m != nil {
+		m.Add(name, delta)
+	}
+}
+
+// countMatch reports a candidate tested against a glob, and whether it
+// matched, to m, which may be nil.
+func countMatch(m Metrics, match bool) {
+	if m == nil {
+		return
+	}
+	m.Add(MetricPathsTested, 1)
+	if match {
+		m.Add(MetricPathsMatched, 1)
+	}
+}
+
+// expvarMu serializes the lookup and publication of the maps of
+// ExpvarMetrics.
+var expvarMu sync.Mutex
+
+// ExpvarMetrics returns a Metrics publishing its counters as an expvar.Map
+// with the given name, which makes them available on /debug/vars. If a map
+// with that name is already published, its counters are reused. If another
+// kind of variable is, ExpvarMetrics returns an error wrapping
+// ErrExpvarInUse.
+//
+// For instance, ExpvarMetrics("shutil") returns a Metrics publishing the
+// counters of this package under the "shutil" variable, to pass to
+// SetMetrics.
+func ExpvarMetrics(name string) (Metrics, error) {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+	switch v := expvar.Get(name).(type) {
+	case nil:
+		return expvar.NewMap(name), nil
+	case *expvar.Map:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrExpvarInUse, name)
+	}
+}
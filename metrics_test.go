@@ -0,0 +1,83 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"expvar"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type testMetrics struct {
+	mu       sync.Mutex
+	counters map[string]int64
+}
+
+func (m *testMetrics) Add(name string, delta int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counters[name] += delta
+}
+
+func TestMetrics(t *testing.T) {
+	m := &testMetrics{counters: make(map[string]int64)}
+	SetMetrics(m)
+	defer SetMetrics(nil)
+
+	g := MustCompileGlob("*.go")
+	g.Match("main.go")
+	g.Match("main.c")
+	g.Match("util.go")
+
+	expected := map[string]int64{
+		MetricGlobsCompiled: 1,
+		MetricPathsTested:   3,
+		MetricPathsMatched:  2,
+	}
+	for name, value := range expected {
+		if m.counters[name] != value {
+			t.Errorf("expected %s to be %d, got %d", name, value, m.counters[name])
+		}
+	}
+
+	if _, err := ParseIgnoreFile(strings.NewReader("*.o\n!main.o\n")); err != nil {
+		t.Fatal(err)
+	}
+	if m.counters[MetricGlobsCompiled] != 3 {
+		t.Errorf("expected each ignore rule to be compiled once, got %d globs", m.counters[MetricGlobsCompiled]-1)
+	}
+
+	SetMetrics(nil)
+	MustCompileGlob("*.go").Match("main.go")
+	if m.counters[MetricGlobsCompiled] != 3 || m.counters[MetricPathsTested] != 3 {
+		t.Errorf("expected counters not to change after disabling metrics")
+	}
+}
+
+func TestExpvarMetrics(t *testing.T) {
+	m, err := ExpvarMetrics("shutil_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Add(MetricPathsTested, 2)
+	m, err = ExpvarMetrics("shutil_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Add(MetricPathsTested, 1)
+
+	v := expvar.Get("shutil_test").(*expvar.Map).Get(MetricPathsTested)
+	if v.String() != "3" {
+		t.Fatalf("expected counter to be 3, got %s", v)
+	}
+
+	expvar.NewInt("shutil_test_int")
+	if _, err := ExpvarMetrics("shutil_test_int"); !errors.Is(err, ErrExpvarInUse) {
+		t.Fatalf("expected ErrExpvarInUse, got %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type globRoute struct {
+	glob    *Glob
+	handler http.Handler
+}
+
+// GlobMux is an HTTP request multiplexer that dispatches requests to the
+// handler whose glob pattern matches the URL path of the request.
+//
+// Request paths are cleaned before matching, so that "/public/../admin" is
+// matched as "/admin". A trailing slash is preserved, so that patterns like
+// "/dir/*/" only match paths ending with a slash.
+//
+// When several patterns match a path, the most specific one wins: patterns
+// with more literal characters take precedence, then patterns with fewer
+// wildcards. Patterns of equal specificity are tried in registration order.
+//
+// The zero value is an empty multiplexer ready to use.
+type GlobMux struct {
+	// NotFound is the handler called when no pattern matches the request.
+	// If nil, http.NotFound is used.
+	NotFound http.Handler
+
+	mu     sync.RWMutex
+	routes []globRoute
+}
+
+// Handle registers the handler for the given glob pattern. It panics if the
+// pattern is invalid or if handler is nil.
+func (mux *GlobMux) Handle(pattern string, handler http.Handler) {
+	if handler == nil {
+		panic("shutil: nil handler")
+	}
+	glob := MustCompileGlob(pattern)
+
+	mux.mu.Lock()
+	defer mux.mu.Unlock()
+
+	// Keep routes ordered from most to least specific, while preserving the
+	// registration order among routes of equal specificity.
+	i := sort.Search(len(mux.routes), func(i int) bool {
+		return moreSpecific(glob, mux.routes[i].glob)
+	})
+	mux.routes = append(mux.routes, globRoute{})
+	copy(mux.routes[i+1:], mux.routes[i:])
+	mux.routes[i] = globRoute{glob: glob, handler: handler}
+}
+
+// HandleFunc registers the handler function for the given glob pattern.
+func (mux *GlobMux) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	if handler == nil {
+		panic("shutil: nil handler")
+	}
+	mux.Handle(pattern, http.HandlerFunc(handler))
+}
+
+// Handler returns the handler to use for the given request, along with the
+// pattern that matched it. If no pattern matches, it returns a nil handler
+// and an empty pattern.
+func (mux *GlobMux) Handler(r *http.Request) (h http.Handler, pattern string) {
+	p := cleanURLPath(r.URL.Path)
+
+	mux.mu.RLock()
+	defer mux.mu.RUnlock()
+
+	for _, route := range mux.routes {
+		if route.glob.Match(p) {
+			return route.handler, route.glob.String()
+		}
+	}
+	return nil, ""
+}
+
+// ServeHTTP dispatches the request to the handler whose pattern most closely
+// matches the request URL path.
+func (mux *GlobMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h, _ := mux.Handler(r)
+	if h == nil {
+		h = mux.NotFound
+	}
+	if h == nil {
+		h = http.HandlerFunc(http.NotFound)
+	}
+	h.ServeHTTP(w, r)
+}
+
+// Middleware returns a handler that dispatches requests matching one of the
+// patterns of mux to the corresponding handler, and all other requests to
+// next.
+func (mux *GlobMux) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h, _ := mux.Handler(r)
+		if h == nil {
+			h = next
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// moreSpecific returns whether a is strictly more specific than b.
+func moreSpecific(a, b *Glob) bool {
+	return CompareSpecificity(a, b) < 0
+}
+
+func cleanURLPath(p string) string {
+	if p == "" {
+		return "/"
+	}
+	if p[0] != '/' {
+		p = "/" + p
+	}
+	cleaned := path.Clean(p)
+	if strings.HasSuffix(p, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+	return cleaned
+}
@@ -0,0 +1,70 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGlobMux(t *testing.T) {
+	respond := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, body)
+		}
+	}
+
+	var mux GlobMux
+	mux.Handle("/**", respond("any"))
+	mux.Handle("/api/**", respond("api"))
+	mux.Handle("/api/v1/*", respond("v1"))
+	mux.Handle("/api/v1/*/", respond("v1-dir"))
+	mux.Handle("/api/v1/status", respond("status"))
+	mux.Handle("/static/*.{css,js}", respond("static"))
+
+	tcases := []struct {
+		Path, Expected string
+	}{
+		{"/", "any"},
+		{"/index.html", "any"},
+		{"/api/v2/users", "api"},
+		{"/api/v1/users", "v1"},
+		{"/api/v1/users/", "v1-dir"},
+		{"/api/v1/status", "status"},
+		{"/api/v1/../v1/status", "status"},
+		{"/static/site.css", "static"},
+		{"/static/site.png", "any"},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("GET", tc.Path, nil))
+			if actual := w.Body.String(); actual != tc.Expected {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+		})
+	}
+
+	t.Run("NotFound", func(t *testing.T) {
+		var mux GlobMux
+		mux.Handle("/api/**", respond("api"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", "/other", nil))
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+
+		w = httptest.NewRecorder()
+		mux.Middleware(respond("next")).ServeHTTP(w, httptest.NewRequest("GET", "/other", nil))
+		if actual := w.Body.String(); actual != "next" {
+			t.Fatalf("expected %q, got %q", "next", actual)
+		}
+	})
+}
@@ -0,0 +1,10 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+//go:build !race
+
+package shutil
+
+const raceEnabled = false
@@ -0,0 +1,299 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// GlobOverlap returns whether some string is matched by both a and b. If so,
+// it also returns the shortest such string as a witness.
+//
+// This is useful to detect when a pattern is shadowed by another, for
+// instance when adding a rule to a routing table. The strings considered are
+// those for which Glob.Match returns true, except that CleanPath is not
+// accounted for: with it, the strings considered are clean paths.
+func GlobOverlap(a, b *Glob) (string, bool) {
+	return overlap(a.inputMachine(), b.inputMachine())
+}
+
+// inputMachine runs a program over strings as Glob.Match does, accounting
+// for the options altering the strings matched.
+type inputMachine struct {
+	prog *program
+
+	// base makes the machine match the final component of strings, as
+	// with MatchBase, and trailingSlash makes trailing slashes optional,
+	// as with TrailingSlash.
+	base          bool
+	trailingSlash bool
+
+	// sep is the separator of the components of the strings matched, if
+	// not "/". The program was compiled with it swapped with "/".
+	sep rune
+}
+
+func (g *Glob) inputMachine() inputMachine {
+	in := g.opts.input()
+	return inputMachine{prog: g.prog, base: in.base, trailingSlash: in.trailingSlash, sep: in.sep}
+}
+
+// inputState is a state of an inputMachine.
+type inputState struct {
+	// set is the set of instructions reached, and leading is true if the
+	// next rune starts a path component.
+	set     []uint32
+	leading bool
+
+	// end is the set of instructions reached by the final component of the
+	// string, as matched with MatchBase: it includes the trailing slash
+	// after which set starts over.
+	end []uint32
+
+	// slash is true if the string ends with a slash, and before is then the
+	// set of instructions reached by the final component without it.
+	slash  bool
+	before []uint32
+}
+
+func (m inputMachine) start() inputState {
+	set := m.prog.start()
+	return inputState{set: set, leading: true, end: set}
+}
+
+// next returns the state following s over r.
+func (m inputMachine) next(s inputState, r rune) inputState {
+	if m.sep != 0 {
+		r = swapRune(r, m.sep, '/')
+	}
+	set := m.prog.next(s.set, r, s.leading)
+	next := inputState{set: set, leading: r == '/', end: set}
+	if r == '/' {
+		next.slash = true
+		if m.trailingSlash {
+			next.before = s.set
+		}
+		if m.base {
+			next.set = m.prog.start()
+		}
+	}
+	return next
+}
+
+func (m inputMachine) accepts(s inputState) bool {
+	if m.prog.accepts(s.end) {
+		return true
+	}
+	if !m.trailingSlash {
+		return false
+	}
+	if s.slash {
+		return m.prog.accepts(s.before)
+	}
+	return m.prog.accepts(m.prog.next(s.end, '/', s.leading))
+}
+
+// dead returns whether neither s nor any string following it is accepted.
+func (m inputMachine) dead(s inputState) bool {
+	return len(s.set) == 0 && !m.base && !m.accepts(s)
+}
+
+func (s inputState) key() string {
+	return fmt.Sprint(s.set, s.leading, s.end, s.slash, s.before)
+}
+
+// overlap explores the product of the automata of a and b breadth-first,
+// looking for a state accepted by both.
+func overlap(a, b inputMachine) (string, bool) {
+	type state struct {
+		a, b   inputState
+		parent int
+		r      rune
+	}
+
+	var seps []rune
+	for _, m := range []inputMachine{a, b} {
+		if m.sep != 0 {
+			seps = append(seps, m.sep)
+		}
+	}
+	alphabet := overlapAlphabet([]*program{a.prog, b.prog}, seps...)
+	states := []state{{a: a.start(), b: b.start(), parent: -1}}
+	seen := map[string]bool{states[0].a.key() + states[0].b.key(): true}
+	for i := 0; i < len(states); i++ {
+		s := states[i]
+		if a.accepts(s.a) && b.accepts(s.b) {
+			var runes []rune
+			for ; s.parent != -1; s = states[s.parent] {
+				runes = append(runes, s.r)
+			}
+			var witness strings.Builder
+			for j := len(runes) - 1; j >= 0; j-- {
+				writeRune(&witness, runes[j])
+			}
+			return witness.String(), true
+		}
+		for _, r := range alphabet {
+			na := a.next(s.a, r)
+			if a.dead(na) {
+				continue
+			}
+			nb := b.next(s.b, r)
+			if b.dead(nb) {
+				continue
+			}
+			if key := na.key() + nb.key(); !seen[key] {
+				seen[key] = true
+				states = append(states, state{a: na, b: nb, parent: i, r: r})
+			}
+		}
+	}
+	return "", false
+}
+
+func stateKey(a, b []uint32, leading bool) string {
+	return fmt.Sprint(a, b, leading)
+}
+
+// start returns the set of instructions the program starts at.
+func (prog *program) start() []uint32 {
+	m := machine{prog: prog}
+	m.clist.reset(len(prog.insts))
+	m.add(&m.clist, 0)
+	return prog.threads(&m.clist)
+}
+
+// next returns the set of instructions reached from set over r. leading is
+// true if r starts a path component.
+func (prog *program) next(set []uint32, r rune, leading bool) []uint32 {
+	m := machine{prog: prog}
+	m.clist.reset(len(prog.insts))
+	for _, pc := range set {
+		if prog.insts[pc].matches(r, false, leading) {
+			m.add(&m.clist, int(pc)+1)
+		}
+	}
+	return prog.threads(&m.clist)
+}
+
+// accepts returns whether set contains a match instruction.
+func (prog *program) accepts(set []uint32) bool {
+	for _, pc := range set {
+		if prog.insts[pc].op == instMatch {
+			return true
+		}
+	}
+	return false
+}
+
+// threads returns the sorted instructions of l that consume input or match,
+// which identify the state of the automaton.
+func (prog *program) threads(l *threadList) []uint32 {
+	var set []uint32
+	for _, pc := range l.dense {
+		switch prog.insts[pc].op {
+		case instRune, instClass, instMatch:
+			set = append(set, pc)
+		}
+	}
+	sort.Slice(set, func(i, j int) bool { return set[i] < set[j] })
+	return set
+}
+
+var (
+	foldRunesOnce sync.Once
+	foldRunes     []rune
+)
+
+// overlapAlphabet returns a set of runes representative of all runes with
+// respect to the instructions of the programs: any rune behaves like one of
+// the returned runes. The runes of seps, which are swapped with "/" before
+// being matched, are kept on their own.
+func overlapAlphabet(progs []*program, seps ...rune) []rune {
+	// Slashes start path components, and end them under MatchBase.
+	bounds := []rune{0, '/', '/' + 1, 0xD800, 0xE000, utf8.MaxRune + 1}
+	for _, sep := range seps {
+		bounds = append(bounds, sep, sep+1)
+	}
+	fold := false
+	for _, prog := range progs {
+		if prog.utf8 == UTF8Bytes {
+			// Invalid bytes are decoded past utf8.MaxRune.
+			bounds = append(bounds, rawBytesEnd)
+		}
+		for _, i := range prog.insts {
+			switch i.op {
+			case instRune:
+				bounds = append(bounds, i.r, i.r+1)
+			case instClass:
+				for _, rg := range i.class.ranges {
+					bounds = append(bounds, rg.lo, rg.hi+1)
+				}
+			default:
+				continue
+			}
+			fold = fold || i.fold
+			if i.period {
+				// Periods affect the matching of periods.
+				bounds = append(bounds, '.', '.'+1)
+			}
+		}
+	}
+	if fold {
+		// Runes with case variants may match instructions that other runes
+		// of their interval do not match, so they are kept on their own.
+		foldRunesOnce.Do(func() {
+			for r := rune(0); r <= unicode.MaxRune; r++ {
+				if unicode.SimpleFold(r) != r {
+					foldRunes = append(foldRunes, r)
+				}
+			}
+		})
+		for _, r := range foldRunes {
+			bounds = append(bounds, r, r+1)
+		}
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i] < bounds[j] })
+
+	var alphabet []rune
+	for i, lo := range bounds[:len(bounds)-1] {
+		hi := bounds[i+1]
+		if lo == hi || 0xD800 <= lo && lo < 0xE000 {
+			// Skip empty intervals and surrogates, which cannot appear in
+			// strings.
+			continue
+		}
+		// Prefer readable witnesses. The alphabet is sorted accordingly below.
+		r := lo
+		for _, p := range "a0_" {
+			if lo <= p && p < hi {
+				r = p
+				break
+			}
+		}
+		alphabet = append(alphabet, r)
+	}
+	sort.SliceStable(alphabet, func(i, j int) bool {
+		return readability(alphabet[i]) < readability(alphabet[j])
+	})
+	return alphabet
+}
+
+// readability ranks runes for use in witnesses, lower being better.
+func readability(r rune) int {
+	switch {
+	case unicode.IsLetter(r) || unicode.IsDigit(r):
+		return 0
+	case unicode.IsPrint(r):
+		return 1
+	}
+	return 2
+}
@@ -0,0 +1,161 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"testing"
+)
+
+func TestGlobOverlap(t *testing.T) {
+	tcases := []struct {
+		A, B    string
+		Overlap bool
+		Witness string
+	}{
+		{"*.go", "main.*", true, "main.go"},
+		{"*", "*", true, ""},
+		{"a", "b", false, ""},
+		{"src/**", "*.go", false, ""},
+		{"src/**", "**/*.go", true, "src/.go"},
+		{"{a,b}/x", "[!a]/*", true, "b/x"},
+		{"a/**/b", "a/*/c", false, ""},
+		{"a/**/b", "a/*/b", true, "a/b"},
+		{"a/?/b", "a/*/b", true, "a/0/b"},
+		{"[[:digit:]]*", "[a-z]*", false, ""},
+		{"file?", "file", false, ""},
+		{"{0..9}{0..9}", "4?", true, "40"},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.A+" "+tc.B, func(t *testing.T) {
+			a, b := MustCompileGlob(tc.A), MustCompileGlob(tc.B)
+			witness, ok := GlobOverlap(a, b)
+			if ok != tc.Overlap {
+				t.Fatalf("expected overlap %v, got %v (witness %q)", tc.Overlap, ok, witness)
+			}
+			if witness != tc.Witness {
+				t.Fatalf("expected witness %q, got %q", tc.Witness, witness)
+			}
+			if ok && (!a.Match(witness) || !b.Match(witness)) {
+				t.Fatalf("witness %q does not match both patterns", witness)
+			}
+		})
+	}
+
+	t.Run("CaseInsensitive", func(t *testing.T) {
+		a, b := foldedGlob(t, "*.GO"), MustCompileGlob("x.go")
+		witness, ok := GlobOverlap(a, b)
+		if !ok || witness != "x.go" {
+			t.Fatalf("expected witness %q, got (%q, %v)", "x.go", witness, ok)
+		}
+		if _, ok := GlobOverlap(a, MustCompileGlob("x.gx")); ok {
+			t.Fatalf("expected no overlap")
+		}
+		k := MustCompileGlob("K")
+		if witness, ok := GlobOverlap(foldedGlob(t, "k"), k); !ok || witness != "K" {
+			t.Fatalf("expected Kelvin sign witness, got (%q, %v)", witness, ok)
+		}
+	})
+}
+
+func TestGlobOverlapOptions(t *testing.T) {
+	tcases := []struct {
+		A       string
+		AOpts   GlobOptions
+		B       string
+		BOpts   GlobOptions
+		Overlap bool
+		Witness string
+	}{
+		{"*.go", GlobOptions{MatchBase: true}, "src/main.go", GlobOptions{}, true, "src/main.go"},
+		{"*.go", GlobOptions{MatchBase: true}, "src/*", GlobOptions{}, true, "src/.go"},
+		{"*.go", GlobOptions{MatchBase: true}, "src/", GlobOptions{}, false, ""},
+		{"a", GlobOptions{MatchBase: true}, "b", GlobOptions{MatchBase: true}, false, ""},
+		{"a", GlobOptions{MatchBase: true}, "*/b/", GlobOptions{}, false, ""},
+		{"b/", GlobOptions{MatchBase: true}, "x/*/b/", GlobOptions{}, true, "x/b/"},
+		{"dir/", GlobOptions{TrailingSlash: true}, "dir", GlobOptions{}, true, "dir"},
+		{"dir", GlobOptions{TrailingSlash: true}, "*/", GlobOptions{}, true, "dir/"},
+		{"a", GlobOptions{TrailingSlash: true}, "a//", GlobOptions{}, false, ""},
+		{"a/", GlobOptions{TrailingSlash: true}, "a//", GlobOptions{}, true, "a//"},
+		{"/", GlobOptions{TrailingSlash: true}, "", GlobOptions{}, true, ""},
+		{"*.go", GlobOptions{TrailingSlash: true, MatchBase: true}, "src/*/", GlobOptions{}, true, "src/.go/"},
+		{"a.*", GlobOptions{Separator: '.'}, "a.b", GlobOptions{}, true, "a.b"},
+		{"a.*", GlobOptions{Separator: '.'}, "a.b.c", GlobOptions{}, false, ""},
+		{"a/*", GlobOptions{Separator: '.'}, "a/b.c", GlobOptions{}, false, ""},
+		{"a/*", GlobOptions{Separator: '.'}, "a/*", GlobOptions{}, true, "a/"},
+		{"a:*", GlobOptions{Separator: ':'}, "a.*", GlobOptions{Separator: '.'}, false, ""},
+		{"*", GlobOptions{Separator: ':'}, "*", GlobOptions{Separator: '.'}, true, ""},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.A+" "+tc.B, func(t *testing.T) {
+			a, err := CompileGlobOptions(tc.A, tc.AOpts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := CompileGlobOptions(tc.B, tc.BOpts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			witness, ok := GlobOverlap(a, b)
+			if ok != tc.Overlap {
+				t.Fatalf("expected overlap %v, got %v (witness %q)", tc.Overlap, ok, witness)
+			}
+			if witness != tc.Witness {
+				t.Fatalf("expected witness %q, got %q", tc.Witness, witness)
+			}
+			if ok && (!a.Match(witness) || !b.Match(witness)) {
+				t.Fatalf("witness %q does not match both patterns", witness)
+			}
+		})
+	}
+}
+
+func foldedGlob(t *testing.T, pattern string) *Glob {
+	t.Helper()
+	g, err := CompileGlobOptions(pattern, GlobOptions{CaseInsensitive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestGlobOverlapMatch(t *testing.T) {
+	patterns := []string{"*.go", "*", "a", "a/", "*/", "a/*", "a/**/b", "/", "{a,a/}"}
+	options := []GlobOptions{
+		{},
+		{MatchBase: true},
+		{TrailingSlash: true},
+		{TrailingSlash: true, MatchBase: true},
+		{Separator: '.'},
+		{Separator: '.', MatchBase: true},
+	}
+	inputs := []string{"", "/", "//", "a", "a/", "a//", "a/b", "a/b/", "b/a", "b/a/", "x.go", "a/x.go", "x.go/", "a.b", "a.go"}
+
+	var globs []*Glob
+	for _, pattern := range patterns {
+		for _, opts := range options {
+			g, err := CompileGlobOptions(pattern, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			globs = append(globs, g)
+		}
+	}
+	for i, a := range globs {
+		for _, b := range globs[i:] {
+			witness, ok := GlobOverlap(a, b)
+			if ok && (!a.Match(witness) || !b.Match(witness)) {
+				t.Errorf("%q %+v, %q %+v: witness %q does not match both patterns", a, a.opts, b, b.opts, witness)
+			}
+			for _, in := range inputs {
+				if !ok && a.Match(in) && b.Match(in) {
+					t.Errorf("%q %+v, %q %+v: expected overlap on %q", a, a.opts, b, b.opts, in)
+				}
+			}
+		}
+	}
+}
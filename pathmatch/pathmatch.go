@@ -0,0 +1,42 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+// Package pathmatch provides a Match function with the contract of
+// path.Match, implemented with shutil globs. Code using path.Match or
+// filepath.Match can be upgraded to the richer syntax of shutil.Glob, like
+// brace groups and "**", by calling pathmatch.Match instead.
+//
+// As with path.Match, a leading "!" is a literal character rather than a
+// negation.
+package pathmatch
+
+import (
+	"path/filepath"
+	"strings"
+
+	"barney.ci/shutil"
+)
+
+// ErrBadPattern is the error returned when a pattern is malformed. It is the
+// same error as filepath.ErrBadPattern.
+var ErrBadPattern = filepath.ErrBadPattern
+
+// Match reports whether name matches the shell pattern, which must match all
+// of name, using "/" as a path separator. The pattern syntax is that of
+// shutil.Glob.
+//
+// The only possible returned error is ErrBadPattern, when pattern is
+// malformed. Unlike path.Match, the whole pattern is always validated, even
+// if name does not match.
+func Match(pattern, name string) (bool, error) {
+	if strings.HasPrefix(pattern, "!") {
+		pattern = `\` + pattern
+	}
+	g, err := shutil.CompileGlob(pattern)
+	if err != nil {
+		return false, ErrBadPattern
+	}
+	return g.Match(name), nil
+}
@@ -0,0 +1,62 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package pathmatch
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tcases := []struct {
+		Pattern, Name string
+		Match         bool
+	}{
+		// Cases from path.Match.
+		{"abc", "abc", true},
+		{"*", "abc", true},
+		{"*c", "abc", true},
+		{"a*", "a", true},
+		{"a*/b", "abc/b", true},
+		{"a*/b", "a/c/b", false},
+		{"a*b*c*d*e*/f", "axbxcxdxe/f", true},
+		{"ab[c]", "abc", true},
+		{"ab[b-d]", "abc", true},
+		{"ab[e-g]", "abc", false},
+		{"ab[^c]", "abc", false},
+		{"ab[^b-d]", "abc", false},
+		{"ab[^e-g]", "abc", true},
+		{`a\*b`, "a*b", true},
+		{`a\*b`, "ab", false},
+		{"a?b", "a☺b", true},
+		{"a[^a]b", "a☺b", true},
+		{"a???b", "a☺b", false},
+		{"*x", "xxx", true},
+		{"!x", "!x", true},
+
+		// Extensions of shutil.Glob.
+		{"src/**/*.go", "src/a/b/main.go", true},
+		{"*.{c,h}", "main.h", true},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			match, err := Match(tc.Pattern, tc.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if match != tc.Match {
+				t.Fatalf("expected %v, got %v", tc.Match, match)
+			}
+		})
+	}
+
+	for _, pattern := range []string{"[", `a\`, "[a-", "[z-a]", "{a"} {
+		if _, err := Match(pattern, "a"); err != filepath.ErrBadPattern {
+			t.Errorf("expected ErrBadPattern for %q, got %v", pattern, err)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// RegexpString returns a regular expression, in the syntax of the regexp
+// package, matching the same strings as the pattern. It allows using globs
+// with systems that accept regular expressions. For instance, the expression
+// of "src/**/*.go" is `(?s)^src/(?:|.*/)[^/]*\.go$`.
+//
+// Like Match, the expression does not account for the leading "!" of negated
+// patterns. It does not account for the Period, SkipHidden, CleanPath and
+// InvalidUTF8 options either: with CleanPath, strings must be cleaned before
+// being matched against the expression.
+func (g *Glob) RegexpString() string {
+	var b strings.Builder
+	b.WriteString(`(?s)`)
+	if g.opts.CaseInsensitive {
+		b.WriteString(`(?i)`)
+	}
+	in := g.opts.input()
+	nodes := g.nodes
+	if in.sep != 0 {
+		nodes = swapNodes(nodes, in.sep, '/')
+	}
+	anchored := !g.opts.Unanchored
+	if !anchored && (in.base || in.trailingSlash) {
+		// The options apply to the whole string, which must contain a match.
+		star := node{op: nodeStar, class: anyRune}
+		nodes = append(append([]node{star}, nodes...), star)
+		anchored = true
+	}
+	if in.trailingSlash {
+		nodes = trailingSlashNodes(nodes, in.separator())
+	}
+	if anchored {
+		b.WriteRune('^')
+	}
+	if in.base {
+		writeRegexpBase(&b, nodes, in.separator())
+	} else {
+		writeRegexp(&b, nodes)
+	}
+	if anchored {
+		b.WriteRune('$')
+	}
+	return b.String()
+}
+
+// writeRegexpBase writes an expression matching the strings whose final
+// component, as matched with MatchBase, matches nodes. sep is the separator
+// of the components.
+func writeRegexpBase(b *strings.Builder, nodes []node, sep rune) {
+	// The final component has no separator, but a trailing one.
+	var alts [][]node
+	noSep, ok := withoutRune(nodes, sep)
+	if ok {
+		alts = append(alts, noSep)
+	}
+	if last, ok := lastRune(nodes, func(c *charClass) ([]node, bool) {
+		return []node{{op: nodeRune, r: sep}}, c.matches(sep)
+	}, func(nodes []node) ([]node, bool) {
+		return withoutRune(nodes, sep)
+	}); ok {
+		alts = append(alts, last)
+	}
+
+	// Strings ending with a separator have a non-empty final component, and
+	// the empty string is its own final component.
+	quoted := regexp.QuoteMeta(string(sep))
+	b.WriteString(`(?:(?:.*` + quoted + `)?`)
+	if base, ok := nonEmpty([]node{{op: nodeAlt, alts: alts}}); ok {
+		writeRegexp(b, base)
+	} else {
+		writeRegexpClass(b, &charClass{})
+	}
+	if ok && nullable(noSep) {
+		b.WriteRune('|')
+	}
+	b.WriteRune(')')
+}
+
+// trailingSlashNodes returns nodes matching the strings of nodes, with or
+// without a trailing separator sep, as matched with TrailingSlash.
+func trailingSlashNodes(nodes []node, sep rune) []node {
+	alts := [][]node{nodes, append(slices.Clone(nodes), node{op: nodeRune, r: sep})}
+	keep := func(nodes []node) ([]node, bool) {
+		return nodes, true
+	}
+
+	// Strings not ending with a separator also match if they do with one.
+	trimmed, ok := lastRune(nodes, func(c *charClass) ([]node, bool) {
+		return nil, c.matches(sep)
+	}, keep)
+	if !ok {
+		return []node{{op: nodeAlt, alts: alts}}
+	}
+	if nullable(trimmed) {
+		alts = append(alts, nil)
+	}
+	if trimmed, ok := lastRune(trimmed, func(c *charClass) ([]node, bool) {
+		c = &charClass{ranges: slices.Clone(c.ranges), negated: c.negated}
+		c.exclude(sep)
+		return []node{{op: nodeClass, class: c}}, c.negated || len(c.ranges) > 0
+	}, keep); ok {
+		alts = append(alts, trimmed)
+	}
+	return []node{{op: nodeAlt, alts: alts}}
+}
+
+// Regexp returns the compiled regular expression returned by RegexpString.
+func (g *Glob) Regexp() *regexp.Regexp {
+	return regexp.MustCompile(g.RegexpString())
+}
+
+func writeRegexp(b *strings.Builder, nodes []node) {
+	for _, n := range nodes {
+		switch n.op {
+		case nodeRune:
+			b.WriteString(regexp.QuoteMeta(string(n.r)))
+		case nodeClass:
+			writeRegexpClass(b, n.class)
+		case nodeStar:
+			writeRegexpClass(b, n.class)
+			b.WriteRune('*')
+		case nodeAlt:
+			b.WriteString(`(?:`)
+			for i, alt := range n.alts {
+				if i > 0 {
+					b.WriteRune('|')
+				}
+				writeRegexp(b, alt)
+			}
+			b.WriteRune(')')
+		}
+	}
+}
+
+func writeRegexpClass(b *strings.Builder, c *charClass) {
+	if len(c.ranges) == 0 {
+		if c.negated {
+			b.WriteString(`.`)
+		} else {
+			b.WriteString(`[^\x00-\x{10FFFF}]`)
+		}
+		return
+	}
+	b.WriteRune('[')
+	if c.negated {
+		b.WriteRune('^')
+	}
+	for _, rg := range c.ranges {
+		writeRegexpClassRune(b, rg.lo)
+		if rg.hi != rg.lo {
+			b.WriteRune('-')
+			writeRegexpClassRune(b, rg.hi)
+		}
+	}
+	b.WriteRune(']')
+}
+
+func writeRegexpClassRune(b *strings.Builder, r rune) {
+	switch r {
+	case '\\', '-', '^', '[', ']':
+		// We still need to escape these
+		b.WriteRune('\\')
+	}
+	b.WriteRune(r)
+}
+
+// The functions below transform sequences of nodes into sequences matching
+// subsets of their strings, to express options altering the strings matched
+// in other pattern languages. They return false if no string is left.
+
+// withoutRune returns nodes matching the strings of nodes not containing r.
+func withoutRune(nodes []node, r rune) ([]node, bool) {
+	out := make([]node, 0, len(nodes))
+	for _, n := range nodes {
+		switch n.op {
+		case nodeRune:
+			if n.r == r {
+				return nil, false
+			}
+		case nodeClass, nodeStar:
+			if n.class.matches(r) {
+				c := &charClass{ranges: slices.Clone(n.class.ranges), negated: n.class.negated}
+				c.exclude(r)
+				n.class = c
+			}
+		case nodeAlt:
+			var alts [][]node
+			for _, alt := range n.alts {
+				if alt, ok := withoutRune(alt, r); ok {
+					alts = append(alts, alt)
+				}
+			}
+			if len(alts) == 0 {
+				return nil, false
+			}
+			n.alts = alts
+		}
+		out = append(out, n)
+	}
+	return out, true
+}
+
+// lastRune returns nodes matching the non-empty strings of nodes, with their
+// last rune, of class c, replaced by the strings last(c) matches, if last
+// returns true. The runes before it are restricted by before.
+func lastRune(nodes []node, last func(c *charClass) ([]node, bool), before func([]node) ([]node, bool)) ([]node, bool) {
+	var alts [][]node
+	for i := len(nodes) - 1; i >= 0; i-- {
+		n := nodes[i]
+		var tails [][]node
+		switch n.op {
+		case nodeRune:
+			if tail, ok := last(&charClass{ranges: []runeRange{{n.r, n.r}}}); ok {
+				tails = append(tails, tail)
+			}
+		case nodeClass:
+			if tail, ok := last(n.class); ok {
+				tails = append(tails, tail)
+			}
+		case nodeStar:
+			if tail, ok := last(n.class); ok {
+				if star, ok := before([]node{n}); ok {
+					tails = append(tails, append(star, tail...))
+				} else {
+					tails = append(tails, tail)
+				}
+			}
+		case nodeAlt:
+			for _, alt := range n.alts {
+				if tail, ok := lastRune(alt, last, before); ok {
+					tails = append(tails, tail)
+				}
+			}
+		}
+		if head, ok := before(nodes[:i]); ok {
+			for _, tail := range tails {
+				alts = append(alts, append(slices.Clone(head), tail...))
+			}
+		}
+		if !nullable(nodes[i : i+1]) {
+			break
+		}
+	}
+	if len(alts) == 0 {
+		return nil, false
+	}
+	return []node{{op: nodeAlt, alts: alts}}, true
+}
+
+// nonEmpty returns nodes matching the non-empty strings of nodes.
+func nonEmpty(nodes []node) ([]node, bool) {
+	var alts [][]node
+	for i, n := range nodes {
+		switch n.op {
+		case nodeRune, nodeClass:
+			alts = append(alts, nodes[i:])
+		case nodeStar:
+			head := node{op: nodeClass, class: n.class}
+			alts = append(alts, append([]node{head}, nodes[i:]...))
+		case nodeAlt:
+			for _, alt := range n.alts {
+				if alt, ok := nonEmpty(alt); ok {
+					alts = append(alts, append(alt, nodes[i+1:]...))
+				}
+			}
+		}
+		if !nullable(nodes[i : i+1]) {
+			break
+		}
+	}
+	if len(alts) == 0 {
+		return nil, false
+	}
+	return []node{{op: nodeAlt, alts: alts}}, true
+}
+
+// nullable returns whether nodes match the empty string.
+func nullable(nodes []node) bool {
+	for _, n := range nodes {
+		switch n.op {
+		case nodeRune, nodeClass:
+			return false
+		case nodeAlt:
+			if !slices.ContainsFunc(n.alts, nullable) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGlobRegexp(t *testing.T) {
+	tcases := []struct {
+		Pattern string
+		Opts    GlobOptions
+		Regexp  string
+	}{
+		{"", GlobOptions{}, `(?s)^$`},
+		{"src/**/*.go", GlobOptions{}, `(?s)^src/(?:|.*/)[^/]*\.go$`},
+		{"a/**", GlobOptions{}, `(?s)^a/.*$`},
+		{"a/*/b", GlobOptions{}, `(?s)^a/(?:|[^/]*/)b$`},
+		{"file?", GlobOptions{}, `(?s)^file[^/]$`},
+		{"{a,b,}.c", GlobOptions{}, `(?s)^(?:a|b|)\.c$`},
+		{`[!]a\-]`, GlobOptions{}, `(?s)^[^\]a\-]$`},
+		{"{0..9}", GlobOptions{}, `(?s)^[0-9]$`},
+		{"*.GO", GlobOptions{CaseInsensitive: true}, `(?s)(?i)^[^/]*\.GO$`},
+		{"b?d", GlobOptions{Unanchored: true}, `(?s)b[^/]d`},
+		{"dir", GlobOptions{TrailingSlash: true}, `(?s)^(?:dir|dir/)$`},
+		{"*.go", GlobOptions{MatchBase: true}, `(?s)^(?:(?:.*/)?(?:(?:[^/][^/]*\.go|\.go)))$`},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, tc.Opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := g.RegexpString(); actual != tc.Regexp {
+				t.Fatalf("expected %s, got %s", tc.Regexp, actual)
+			}
+			if actual := g.Regexp().String(); actual != tc.Regexp {
+				t.Fatalf("expected compiled %s, got %s", tc.Regexp, actual)
+			}
+		})
+	}
+}
+
+func TestGlobRegexpOptions(t *testing.T) {
+	patterns := []string{"*.go", "*", "**", "{,a}", "a", "a/", "*/", "?", "[!x]*", "a/**/b", "/", "{a,a/}", "a/[!b]"}
+	options := []GlobOptions{
+		{MatchBase: true},
+		{MatchBase: true, Unanchored: true},
+		{MatchBase: true, CrossSeparators: true},
+		{MatchBase: true, Separator: '.'},
+		{TrailingSlash: true},
+		{TrailingSlash: true, Unanchored: true},
+		{TrailingSlash: true, CrossSeparators: true},
+		{TrailingSlash: true, Separator: '.'},
+		{TrailingSlash: true, MatchBase: true},
+	}
+	inputs := []string{
+		"", "/", "//", "a", "a/", "a//", "a.", "a/c", "a/c/", "a/b//", "a/b", "a/b/", "ab/c", "a/x/b",
+		"main.go", "cmd/main.go", "cmd/main.go/", "x/.go", "a.b", "a.b.", "x.a.b",
+	}
+
+	for _, pattern := range patterns {
+		for _, opts := range options {
+			t.Run(fmt.Sprintf("%s %+v", pattern, opts), func(t *testing.T) {
+				g, err := CompileGlobOptions(pattern, opts)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				re := g.Regexp()
+				for _, in := range inputs {
+					if expected, actual := g.Match(in), re.MatchString(in); actual != expected {
+						t.Errorf("%q: expected %v, got %v with %s", in, expected, actual, re)
+					}
+				}
+			})
+		}
+	}
+}
+
+func ExampleGlob_RegexpString() {
+	fmt.Println(MustCompileGlob("src/**/*.go").RegexpString())
+	// Output: (?s)^src/(?:|.*/)[^/]*\.go$
+}
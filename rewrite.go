@@ -0,0 +1,127 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownCapture       = errors.New("reference to unknown capture")
+	ErrUnterminatedTemplate = errors.New("unterminated capture reference")
+)
+
+// Rewrite maps the paths matching a glob pattern to new paths, built from a
+// template referencing the captures of the pattern (see Glob.Capture), like
+// the pattern rules of make(1). For instance, the rewrite from
+// "src/**/*.proto" to "gen/{1}/{2}.pb.go" maps "src/api/v1/user.proto" to
+// "gen/api/v1/user.pb.go".
+//
+// In templates, "{n}" is replaced by the n-th capture of the pattern,
+// starting at 1, and "{0}" by the whole path matched. A backslash escapes
+// the next character, such that `\{` is a literal "{".
+type Rewrite struct {
+	glob     *Glob
+	template string
+	parts    []rewritePart
+}
+
+// rewritePart is either a literal, or a reference to capture index if lit
+// is empty.
+type rewritePart struct {
+	lit   string
+	index int
+}
+
+// CompileRewrite compiles a rewrite from the paths matching pattern to
+// template.
+func CompileRewrite(pattern, template string) (*Rewrite, error) {
+	g, err := CompileGlob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return NewRewrite(g, template)
+}
+
+// NewRewrite returns a rewrite from the paths matching g to template.
+func NewRewrite(g *Glob, template string) (*Rewrite, error) {
+	rw := &Rewrite{glob: g, template: template}
+	var lit strings.Builder
+	flush := func() {
+		if lit.Len() > 0 {
+			rw.parts = append(rw.parts, rewritePart{lit: lit.String()})
+			lit.Reset()
+		}
+	}
+	for i := 0; i < len(template); i++ {
+		switch c := template[i]; c {
+		case '\\':
+			if i+1 < len(template) {
+				i++
+			}
+			lit.WriteByte(template[i])
+		case '{':
+			end := strings.IndexByte(template[i:], '}')
+			if end == -1 {
+				return nil, &GlobError{Pattern: template, Index: i, Err: ErrUnterminatedTemplate}
+			}
+			index, err := strconv.Atoi(template[i+1 : i+end])
+			if err != nil || index < 0 || index > g.prog.ncap {
+				return nil, &GlobError{Pattern: template, Index: i, Err: ErrUnknownCapture}
+			}
+			flush()
+			rw.parts = append(rw.parts, rewritePart{index: index})
+			i += end
+		default:
+			lit.WriteByte(c)
+		}
+	}
+	flush()
+	return rw, nil
+}
+
+// MustCompileRewrite is like CompileRewrite, but panics if the function returned an error.
+func MustCompileRewrite(pattern, template string) *Rewrite {
+	rw, err := CompileRewrite(pattern, template)
+	if err != nil {
+		panic(err)
+	}
+	return rw
+}
+
+// Rewrite returns path rewritten according to the template, if path matches
+// the pattern. Otherwise, it returns false.
+func (rw *Rewrite) Rewrite(path string) (string, bool) {
+	captures, ok := rw.glob.Capture(path)
+	if !ok {
+		return "", false
+	}
+	var b strings.Builder
+	for _, part := range rw.parts {
+		switch {
+		case part.lit != "":
+			b.WriteString(part.lit)
+		case part.index == 0:
+			b.WriteString(path)
+		default:
+			b.WriteString(captures[part.index-1])
+		}
+	}
+	return b.String(), true
+}
+
+// Glob returns the glob of the rewrite.
+func (rw *Rewrite) Glob() *Glob {
+	return rw.glob
+}
+
+// String returns the pattern and template of the rewrite, separated by an
+// arrow, as in "src/**/*.proto -> gen/{1}/{2}.pb.go".
+func (rw *Rewrite) String() string {
+	return rw.glob.String() + " -> " + rw.template
+}
@@ -0,0 +1,73 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRewrite(t *testing.T) {
+	tcases := []struct {
+		Pattern, Template string
+		Path, Expected    string
+		Match             bool
+	}{
+		{"src/**/*.proto", "gen/{1}/{2}.pb.go", "src/api/v1/user.proto", "gen/api/v1/user.pb.go", true},
+		{"src/**/*.proto", "gen/{1}/{2}.pb.go", "src/user.proto", "gen//user.pb.go", true},
+		{"src/**/*.proto", "gen/{1}/{2}.pb.go", "lib/user.proto", "", false},
+		{"*.{c,cc}", "obj/{1}.o", "main.cc", "obj/main.o", true},
+		{"*.{c,cc}", "{2}/{0}", "main.c", "c/main.c", true},
+		{"*", `\{{1}\}`, "x", "{x}", true},
+		{"*.go", "static", "main.go", "static", true},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern+" "+tc.Path, func(t *testing.T) {
+			rw, err := CompileRewrite(tc.Pattern, tc.Template)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual, ok := rw.Rewrite(tc.Path)
+			if ok != tc.Match || actual != tc.Expected {
+				t.Fatalf("expected %q, %v, got %q, %v", tc.Expected, tc.Match, actual, ok)
+			}
+		})
+	}
+
+	if s := MustCompileRewrite("*.c", "{1}.o").String(); s != "*.c -> {1}.o" {
+		t.Errorf("unexpected string %q", s)
+	}
+}
+
+func TestRewriteErrors(t *testing.T) {
+	tcases := []struct {
+		Pattern, Template string
+		Index             int
+		Err               error
+	}{
+		{"*.c", "{2}.o", 0, ErrUnknownCapture},
+		{"*.c", "obj/{x}.o", 4, ErrUnknownCapture},
+		{"*.c", "obj/{-1}.o", 4, ErrUnknownCapture},
+		{"*.c", "obj/{1", 4, ErrUnterminatedTemplate},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.Template, func(t *testing.T) {
+			_, err := CompileRewrite(tc.Pattern, tc.Template)
+			var gerr *GlobError
+			if !errors.As(err, &gerr) || !errors.Is(err, tc.Err) {
+				t.Fatalf("expected %v, got %v", tc.Err, err)
+			}
+			if gerr.Index != tc.Index {
+				t.Fatalf("expected error at index %d, got %d", tc.Index, gerr.Index)
+			}
+		})
+	}
+
+	if _, err := CompileRewrite("{a", "x"); !errors.Is(err, ErrUnterminatedBrace) {
+		t.Errorf("expected %v, got %v", ErrUnterminatedBrace, err)
+	}
+}
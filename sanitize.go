@@ -0,0 +1,150 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrMalformedEscape = errors.New("malformed escape sequence")
+)
+
+type sanitizeConfig struct {
+	replacement string
+	reversible  bool
+}
+
+// A SanitizeOption alters the behaviour of SanitizeFilename.
+type SanitizeOption func(*sanitizeConfig)
+
+// SanitizeReplacement sets the string that replaces unsafe characters. It
+// defaults to "_", and should itself only contain safe characters.
+func SanitizeReplacement(replacement string) SanitizeOption {
+	return func(cfg *sanitizeConfig) {
+		cfg.replacement = replacement
+	}
+}
+
+// SanitizeReversible makes SanitizeFilename encode unsafe bytes as "%XX"
+// hexadecimal escapes rather than replacing them, such that the original
+// string can be recovered with UnsanitizeFilename.
+func SanitizeReversible() SanitizeOption {
+	return func(cfg *sanitizeConfig) {
+		cfg.reversible = true
+	}
+}
+
+// isSafeFilenameByte returns true if c belongs to the POSIX portable filename
+// character set. These characters never need quoting in a shell.
+func isSafeFilenameByte(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '.' || c == '_' || c == '-'
+}
+
+// isReservedFilename returns true if name is reserved on Windows, regardless
+// of its extension.
+func isReservedFilename(name string) bool {
+	if i := strings.IndexByte(name, '.'); i != -1 {
+		name = name[:i]
+	}
+	switch strings.ToUpper(name) {
+	case "CON", "PRN", "AUX", "NUL":
+		return true
+	}
+	if len(name) == 4 && name[3] >= '1' && name[3] <= '9' {
+		switch strings.ToUpper(name[:3]) {
+		case "COM", "LPT":
+			return true
+		}
+	}
+	return false
+}
+
+// SanitizeFilename returns a portable filename derived from s, that is safe
+// to use unquoted in a shell. For example, SanitizeFilename("feature/my branch")
+// returns "feature_my_branch".
+//
+// The result only contains characters from the POSIX portable filename
+// character set (letters, digits, ".", "_" and "-"). Other characters,
+// including path separators and control characters, are replaced. Leading
+// dashes and dots, which would make the name look like an option or a hidden
+// file, are stripped, as are trailing dots, which Windows ignores. Names
+// reserved on Windows, like "CON" or "nul.txt", are prefixed with the
+// replacement. The result is never empty.
+//
+// With SanitizeReversible, unsafe bytes are escaped as "%XX" instead, and
+// UnsanitizeFilename recovers s from the result.
+func SanitizeFilename(s string, opts ...SanitizeOption) string {
+	cfg := sanitizeConfig{replacement: "_"}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.reversible {
+		return escapeFilename(s)
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		if r < 0x80 && isSafeFilenameByte(byte(r)) {
+			b.WriteRune(r)
+		} else {
+			b.WriteString(cfg.replacement)
+		}
+	}
+	name := strings.TrimRight(strings.TrimLeft(b.String(), "-."), ".")
+	if name == "" || isReservedFilename(name) {
+		name = cfg.replacement + name
+	}
+	return name
+}
+
+func escapeFilename(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		escape := !isSafeFilenameByte(c) ||
+			i == 0 && (c == '-' || c == '.' || isReservedFilename(s)) ||
+			i == len(s)-1 && c == '.'
+		if escape {
+			fmt.Fprintf(&b, "%%%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	if b.Len() == 0 {
+		return "%"
+	}
+	return b.String()
+}
+
+// UnsanitizeFilename recovers the string encoded by SanitizeFilename with the
+// SanitizeReversible option.
+func UnsanitizeFilename(name string) (string, error) {
+	if name == "%" {
+		return "", nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] != '%' {
+			b.WriteByte(name[i])
+			continue
+		}
+		if i+3 > len(name) {
+			return "", ErrMalformedEscape
+		}
+		c, err := strconv.ParseUint(name[i+1:i+3], 16, 8)
+		if err != nil {
+			return "", ErrMalformedEscape
+		}
+		b.WriteByte(byte(c))
+		i += 2
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,73 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"testing"
+)
+
+func TestSanitizeFilename(t *testing.T) {
+	tcases := []struct {
+		In, Expected string
+		Opts         []SanitizeOption
+	}{
+		{"file.txt", "file.txt", nil},
+		{"feature/my branch", "feature_my_branch", nil},
+		{"feature/my branch", "feature-my-branch", []SanitizeOption{SanitizeReplacement("-")}},
+		{`a\b:c*d?e"f<g>h|i`, "a_b_c_d_e_f_g_h_i", nil},
+		{"tab\there\x00", "tab_here_", nil},
+		{"--rf", "rf", nil},
+		{"../../etc/passwd", "_.._etc_passwd", nil},
+		{".hidden", "hidden", nil},
+		{"trailing...", "trailing", nil},
+		{"", "_", nil},
+		{"...", "_", nil},
+		{"CON", "_CON", nil},
+		{"nul.txt", "_nul.txt", nil},
+		{"com1", "_com1", nil},
+		{"com10", "com10", nil},
+		{"console", "console", nil},
+		{"café", "caf_", nil},
+
+		{"feature/my branch", "feature%2Fmy%20branch", []SanitizeOption{SanitizeReversible()}},
+		{"-rf", "%2Drf", []SanitizeOption{SanitizeReversible()}},
+		{".hidden.", "%2Ehidden%2E", []SanitizeOption{SanitizeReversible()}},
+		{"CON", "%43ON", []SanitizeOption{SanitizeReversible()}},
+		{"100%", "100%25", []SanitizeOption{SanitizeReversible()}},
+		{"café", "caf%C3%A9", []SanitizeOption{SanitizeReversible()}},
+		{"", "%", []SanitizeOption{SanitizeReversible()}},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.In, func(t *testing.T) {
+			actual := SanitizeFilename(tc.In, tc.Opts...)
+			if actual != tc.Expected {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+		})
+	}
+
+	t.Run("Reversible", func(t *testing.T) {
+		for _, in := range []string{"", "%", "-", ".", "..", "a/b", "CON.txt", "café", "\x00\xff", "%41"} {
+			name := SanitizeFilename(in, SanitizeReversible())
+			actual, err := UnsanitizeFilename(name)
+			if err != nil {
+				t.Fatalf("%q: unexpected error: %v", in, err)
+			}
+			if actual != in {
+				t.Fatalf("%q: round-tripped through %q to %q", in, name, actual)
+			}
+		}
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		for _, name := range []string{"%4", "a%", "%zz"} {
+			if _, err := UnsanitizeFilename(name); err == nil {
+				t.Errorf("%q: unexpected success", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,33 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"iter"
+)
+
+// MatchSeq returns an iterator over the strings of seq matching the glob
+// pattern. Strings are matched lazily as the iterator is consumed, which
+// allows filtering streams of paths without collecting them first.
+func (g *Glob) MatchSeq(seq iter.Seq[string]) iter.Seq[string] {
+	return filterSeq(seq, g.Match)
+}
+
+// MatchSeq returns an iterator over the strings of seq matching at least one
+// pattern of the set. See Glob.MatchSeq for details.
+func (s *GlobSet) MatchSeq(seq iter.Seq[string]) iter.Seq[string] {
+	return filterSeq(seq, s.Match)
+}
+
+func filterSeq(seq iter.Seq[string], match func(string) bool) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for s := range seq {
+			if match(s) && !yield(s) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestMatchSeq(t *testing.T) {
+	paths := []string{"main.go", "README.md", "cmd/main.go", "x.go", "docs/a.md"}
+
+	g := MustCompileGlob("*.go")
+	if actual, expected := slices.Collect(g.MatchSeq(slices.Values(paths))), []string{"main.go", "x.go"}; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %q, got %q", expected, actual)
+	}
+
+	set := MustCompileGlobSet([]string{"*.md", "docs/**"})
+	if actual, expected := slices.Collect(set.MatchSeq(slices.Values(paths))), []string{"README.md", "docs/a.md"}; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %q, got %q", expected, actual)
+	}
+
+	// Stopping early must stop consuming the source.
+	var consumed int
+	source := func(yield func(string) bool) {
+		for _, path := range paths {
+			consumed++
+			if !yield(path) {
+				return
+			}
+		}
+	}
+	for range g.MatchSeq(source) {
+		break
+	}
+	if consumed != 1 {
+		t.Fatalf("expected 1 path to be consumed, got %d", consumed)
+	}
+}
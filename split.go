@@ -0,0 +1,143 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnterminatedQuote  = errors.New("unterminated quote")
+	ErrUnterminatedEscape = errors.New("unterminated escape")
+)
+
+// SplitError represents a syntax error in a string being split into words.
+type SplitError struct {
+
+	// Input is the erroneous input.
+	Input string
+
+	// Index is the byte offset of the start of the erroneous construct.
+	Index int
+
+	// Err is the concrete underlying error.
+	Err error
+}
+
+func (err *SplitError) Error() string {
+	return fmt.Sprintf("split error: in %q at index %d: %v", err.Input, err.Index, err.Err)
+}
+
+func (err *SplitError) Unwrap() error {
+	return err.Err
+}
+
+// A Token is a word of a command line, as returned by SplitTokens.
+type Token struct {
+
+	// Value is the word, with quotes and escapes removed.
+	Value string
+
+	// Raw is the word as spelled in the input, quotes and escapes included.
+	Raw string
+
+	// Offset is the byte offset of Raw in the input.
+	Offset int
+}
+
+// Split splits s into words the way a POSIX shell would, honoring single
+// quotes, double quotes and backslash escapes. It undoes Quote, except for
+// empty arguments and arguments containing tabs or newlines, which Quote
+// leaves unquoted.
+// For example, Split("rm 'abc def' hij") returns []string{"rm", "abc def", "hij"}.
+//
+// Split does not perform any expansion: "$", "*" and the like are kept as is.
+// It returns a *SplitError if s contains an unterminated quote or ends with
+// an unescaped backslash.
+func Split(s string) ([]string, error) {
+	tokens, err := SplitTokens(s)
+	if err != nil {
+		return nil, err
+	}
+	words := make([]string, len(tokens))
+	for i, tok := range tokens {
+		words[i] = tok.Value
+	}
+	return words, nil
+}
+
+// SplitTokens is like Split, but returns the words as tokens annotated with
+// their position and original spelling in s.
+func SplitTokens(s string) ([]Token, error) {
+	var (
+		tokens []Token
+		word   strings.Builder
+	)
+	for i := 0; i < len(s); {
+		if isBlank(s[i]) {
+			i++
+			continue
+		}
+		if strings.HasPrefix(s[i:], "\\\n") {
+			i += 2
+			continue
+		}
+
+		start := i
+		word.Reset()
+		for i < len(s) && !isBlank(s[i]) {
+			switch c := s[i]; c {
+			case '\\':
+				if i+1 == len(s) {
+					return nil, &SplitError{Input: s, Index: i, Err: ErrUnterminatedEscape}
+				}
+				// A backslash-newline is a line continuation.
+				if s[i+1] != '\n' {
+					word.WriteByte(s[i+1])
+				}
+				i += 2
+			case '\'':
+				end := strings.IndexByte(s[i+1:], '\'')
+				if end == -1 {
+					return nil, &SplitError{Input: s, Index: i, Err: ErrUnterminatedQuote}
+				}
+				word.WriteString(s[i+1 : i+1+end])
+				i += end + 2
+			case '"':
+				quote := i
+				for i++; ; i++ {
+					if i == len(s) {
+						return nil, &SplitError{Input: s, Index: quote, Err: ErrUnterminatedQuote}
+					}
+					if s[i] == '"' {
+						i++
+						break
+					}
+					// Within double quotes, backslashes only escape characters
+					// that are otherwise special.
+					if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("$`\"\\\n", s[i+1]) != -1 {
+						i++
+						if s[i] == '\n' {
+							continue
+						}
+					}
+					word.WriteByte(s[i])
+				}
+			default:
+				word.WriteByte(c)
+				i++
+			}
+		}
+		tokens = append(tokens, Token{Value: word.String(), Raw: s[start:i], Offset: start})
+	}
+	return tokens, nil
+}
+
+func isBlank(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
@@ -0,0 +1,99 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	for i, tc := range []struct {
+		in   string
+		argv []string
+	}{
+		{"", nil},
+		{"   ", nil},
+		{"hello", []string{"hello"}},
+		{"hello  world", []string{"hello", "world"}},
+		{"'he llo' \"wo rld\"", []string{"he llo", "wo rld"}},
+		{"he\\ l\\'lo wo\\ r\\'ld", []string{"he l'lo", "wo r'ld"}},
+		{"a''b ''", []string{"ab", ""}},
+		{`"a\"b\c\$"`, []string{`a"b\c$`}},
+		{"'a\\b'", []string{`a\b`}},
+		{"a\\\nb \\\n c", []string{"ab", "c"}},
+		{"$HOME *.go", []string{"$HOME", "*.go"}},
+	} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			act, err := Split(tc.in)
+			if err != nil {
+				t.Fatalf("splitting %q: unexpected error: %v", tc.in, err)
+			}
+			if len(act) == 0 && len(tc.argv) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(act, tc.argv) {
+				t.Errorf("splitting %q: got %q, expected %q", tc.in, act, tc.argv)
+			}
+		})
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		argv := []string{"rm", "-rf", "abc def", "it's", "~user", "$x", "a;b", ""}
+		act, err := Split(Quote(argv))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(act, argv[:len(argv)-1]) {
+			t.Errorf("got %q, expected %q", act, argv[:len(argv)-1])
+		}
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		for _, tc := range []struct {
+			in    string
+			index int
+			err   error
+		}{
+			{"echo 'abc", 5, ErrUnterminatedQuote},
+			{`echo "abc`, 5, ErrUnterminatedQuote},
+			{`echo "abc\"`, 5, ErrUnterminatedQuote},
+			{`echo abc\`, 8, ErrUnterminatedEscape},
+		} {
+			_, err := Split(tc.in)
+			var serr *SplitError
+			if !errors.As(err, &serr) || !errors.Is(err, tc.err) {
+				t.Fatalf("splitting %q: expected %v, got %v", tc.in, tc.err, err)
+			}
+			if serr.Index != tc.index {
+				t.Errorf("splitting %q: expected error at index %d, got %d", tc.in, tc.index, serr.Index)
+			}
+		}
+	})
+}
+
+func TestSplitTokens(t *testing.T) {
+	in := `cmd --flag='a b'  "c"d`
+	expected := []Token{
+		{Value: "cmd", Raw: "cmd", Offset: 0},
+		{Value: "--flag=a b", Raw: "--flag='a b'", Offset: 4},
+		{Value: "cd", Raw: `"c"d`, Offset: 18},
+	}
+	act, err := SplitTokens(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(act, expected) {
+		t.Fatalf("got %+v, expected %+v", act, expected)
+	}
+	for _, tok := range act {
+		if in[tok.Offset:tok.Offset+len(tok.Raw)] != tok.Raw {
+			t.Errorf("token %+v does not point back into the input", tok)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUntranslatable = errors.New("pattern cannot be translated")
+	ErrInvalidEscape  = errors.New("invalid escape character")
+)
+
+// SQLLike returns a SQL LIKE pattern matching the same strings as the glob
+// pattern, with escape as the escape character, to be used as in:
+//
+//	path LIKE $1 ESCAPE '\'
+//
+// LIKE only has wildcards matching any character and any string, including
+// slashes. Thus only literal patterns and "**" can be translated, unless the
+// glob was compiled with CrossSeparators. Other constructs, like brace
+// groups and classes, make SQLLike return an error wrapping
+// ErrUntranslatable; SQLSimilar translates more patterns.
+//
+// Negated patterns, and the CaseInsensitive, Period, CleanPath,
+// TrailingSlash, MatchBase, Separator and SkipHidden options cannot be
+// translated either, nor can invalid UTF-8 matched as raw bytes.
+func (g *Glob) SQLLike(escape rune) (string, error) {
+	if err := g.checkSQL(escape, `%_`); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if g.opts.Unanchored {
+		b.WriteRune('%')
+	}
+	if err := g.writeSQLLike(&b, g.nodes, escape); err != nil {
+		return "", err
+	}
+	if g.opts.Unanchored {
+		b.WriteRune('%')
+	}
+	return b.String(), nil
+}
+
+// SQLSimilar returns a SQL SIMILAR TO expression matching the same strings
+// as the glob pattern, with escape as the escape character, to be used as
+// in:
+//
+//	path SIMILAR TO $1 ESCAPE '\'
+//
+// Unlike SQLLike, SQLSimilar translates stars, brace groups and most
+// classes. It returns an error wrapping ErrUntranslatable for classes
+// containing one of `[]^-\` or the escape character, for negated patterns,
+// and for the options SQLLike cannot translate either.
+func (g *Glob) SQLSimilar(escape rune) (string, error) {
+	if err := g.checkSQL(escape, sqlSimilarMeta); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if g.opts.Unanchored {
+		b.WriteRune('%')
+	}
+	if err := g.writeSQLSimilar(&b, g.nodes, escape); err != nil {
+		return "", err
+	}
+	if g.opts.Unanchored {
+		b.WriteRune('%')
+	}
+	return b.String(), nil
+}
+
+// sqlSimilarMeta are the special characters of SIMILAR TO expressions.
+const sqlSimilarMeta = `%_|*+?{}()[]`
+
+// checkSQL returns an error if the glob cannot be translated to SQL, or if
+// escape is not a valid escape character for a pattern language whose
+// special characters are meta.
+func (g *Glob) checkSQL(escape rune, meta string) error {
+	if escape == 0 || strings.ContainsRune(meta, escape) {
+		return fmt.Errorf("%w: %q", ErrInvalidEscape, escape)
+	}
+	switch {
+	case g.negated:
+		return g.untranslatable("negated pattern")
+	case g.opts.CaseInsensitive:
+		return g.untranslatable("case-insensitive pattern")
+	case g.opts.Period, g.opts.CleanPath, g.opts.TrailingSlash, g.opts.MatchBase, g.opts.Separator != 0, g.opts.SkipHidden:
+		return g.untranslatable("unsupported option")
+	case g.rawBytes():
+		return g.untranslatable("invalid UTF-8 matched as raw bytes")
+	}
+	return nil
+}
+
+func (g *Glob) untranslatable(reason string) error {
+	return fmt.Errorf("%w: %q: %s", ErrUntranslatable, g.pattern, reason)
+}
+
+func (g *Glob) writeSQLLike(b *strings.Builder, nodes []node, escape rune) error {
+	for _, n := range nodes {
+		switch {
+		case n.op == nodeRune:
+			if n.r == '%' || n.r == '_' || n.r == escape {
+				b.WriteRune(escape)
+			}
+			b.WriteRune(n.r)
+		case n.op == nodeClass && n.class == anyRune:
+			b.WriteRune('_')
+		case n.op == nodeStar && n.class == anyRune:
+			b.WriteRune('%')
+		default:
+			return g.untranslatable("not expressible with LIKE")
+		}
+	}
+	return nil
+}
+
+func (g *Glob) writeSQLSimilar(b *strings.Builder, nodes []node, escape rune) error {
+	for _, n := range nodes {
+		switch n.op {
+		case nodeRune:
+			if strings.ContainsRune(sqlSimilarMeta, n.r) || n.r == escape {
+				b.WriteRune(escape)
+			}
+			b.WriteRune(n.r)
+		case nodeClass:
+			if n.class == anyRune {
+				b.WriteRune('_')
+				break
+			}
+			if err := g.writeSQLClass(b, n.class, escape); err != nil {
+				return err
+			}
+		case nodeStar:
+			if n.class == anyRune {
+				b.WriteRune('%')
+				break
+			}
+			if err := g.writeSQLClass(b, n.class, escape); err != nil {
+				return err
+			}
+			b.WriteRune('*')
+		case nodeAlt:
+			b.WriteRune('(')
+			for i, alt := range n.alts {
+				if i > 0 {
+					b.WriteRune('|')
+				}
+				if err := g.writeSQLSimilar(b, alt, escape); err != nil {
+					return err
+				}
+			}
+			b.WriteRune(')')
+		}
+	}
+	return nil
+}
+
+func (g *Glob) writeSQLClass(b *strings.Builder, c *charClass, escape rune) error {
+	if len(c.ranges) == 0 {
+		return g.untranslatable("empty class")
+	}
+	b.WriteRune('[')
+	if c.negated {
+		b.WriteRune('^')
+	}
+	for _, rg := range c.ranges {
+		for _, r := range []rune{rg.lo, rg.hi} {
+			if strings.ContainsRune(`[]^-\`, r) || r == escape {
+				return g.untranslatable(fmt.Sprintf("class containing %q", r))
+			}
+		}
+		b.WriteRune(rg.lo)
+		if rg.hi != rg.lo {
+			b.WriteRune('-')
+			b.WriteRune(rg.hi)
+		}
+	}
+	b.WriteRune(']')
+	return nil
+}
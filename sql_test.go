@@ -0,0 +1,77 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGlobSQL(t *testing.T) {
+	tcases := []struct {
+		Pattern string
+		Opts    GlobOptions
+		Like    string
+		Similar string
+	}{
+		{"src/main.go", GlobOptions{}, "src/main.go", "src/main.go"},
+		{"src/**", GlobOptions{}, "src/%", "src/%"},
+		{"100%_done", GlobOptions{}, `100\%\_done`, `100\%\_done`},
+		{`a\\b`, GlobOptions{}, `a\\b`, `a\\b`},
+		{"src/*.go", GlobOptions{}, "", `src/[^/]*.go`},
+		{"src/?.go", GlobOptions{CrossSeparators: true}, "src/_.go", "src/_.go"},
+		{"*.go", GlobOptions{CrossSeparators: true}, "%.go", "%.go"},
+		{"**/*.{c,h}", GlobOptions{}, "", `(|%/)[^/]*.(c|h)`},
+		{"file[0-9a]", GlobOptions{}, "", "file[0-9a]"},
+		{"file[!x]", GlobOptions{}, "", "file[^x]"},
+		{"(a|b)+", GlobOptions{}, "(a|b)+", `\(a\|b\)\+`},
+		{"main.go", GlobOptions{Unanchored: true}, "%main.go%", "%main.go%"},
+		{"file[]x]", GlobOptions{}, "", ""},
+		{"!*.go", GlobOptions{}, "", ""},
+		{"*.go", GlobOptions{CaseInsensitive: true}, "", ""},
+		{"*.go", GlobOptions{MatchBase: true}, "", ""},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			g, err := CompileGlobOptions(tc.Pattern, tc.Opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			like, err := g.SQLLike('\\')
+			if tc.Like == "" {
+				if !errors.Is(err, ErrUntranslatable) {
+					t.Errorf("expected LIKE error %v, got %q, %v", ErrUntranslatable, like, err)
+				}
+			} else if err != nil || like != tc.Like {
+				t.Errorf("expected LIKE %q, got %q, %v", tc.Like, like, err)
+			}
+			similar, err := g.SQLSimilar('\\')
+			if tc.Similar == "" {
+				if !errors.Is(err, ErrUntranslatable) {
+					t.Errorf("expected SIMILAR TO error %v, got %q, %v", ErrUntranslatable, similar, err)
+				}
+			} else if err != nil || similar != tc.Similar {
+				t.Errorf("expected SIMILAR TO %q, got %q, %v", tc.Similar, similar, err)
+			}
+		})
+	}
+
+	t.Run("Escape", func(t *testing.T) {
+		g := MustCompileGlob("a_b!c")
+		if like, err := g.SQLLike('!'); err != nil || like != "a!_b!!c" {
+			t.Errorf("expected %q, got %q, %v", "a!_b!!c", like, err)
+		}
+		for _, escape := range []rune{0, '%', '_'} {
+			if _, err := g.SQLLike(escape); !errors.Is(err, ErrInvalidEscape) {
+				t.Errorf("%q: expected %v, got %v", escape, ErrInvalidEscape, err)
+			}
+		}
+		if _, err := g.SQLSimilar('|'); !errors.Is(err, ErrInvalidEscape) {
+			t.Errorf("expected %v, got %v", ErrInvalidEscape, err)
+		}
+	})
+}
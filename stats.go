@@ -0,0 +1,100 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"sync/atomic"
+)
+
+// PatternStats reports how a pattern of a set was used, as recorded by the
+// sets returned by GlobSet.WithStats and IgnoreSet.WithStats. A pattern that
+// never matched is likely a dead rule.
+type PatternStats struct {
+	// Pattern is the pattern, as returned by Glob.String. The patterns of
+	// re-including ignore rules are prefixed with "!".
+	Pattern string
+
+	// Evaluated counts the strings the pattern was tested against.
+	Evaluated int64
+
+	// Matched counts the strings the pattern matched.
+	Matched int64
+}
+
+// patternCounters are the counters of a pattern, updated atomically.
+type patternCounters struct {
+	evaluated, matched atomic.Int64
+}
+
+func (c *patternCounters) record(matched bool) {
+	c.evaluated.Add(1)
+	if matched {
+		c.matched.Add(1)
+	}
+}
+
+// WithStats returns a set matching the same strings as s, which records how
+// many strings each of its patterns is tested against and matches, as
+// reported by Stats. Recording requires testing the patterns one by one,
+// which makes matching slower: the set s itself does not record anything.
+//
+// Each pattern is tested against every string the set is tested against.
+// The patterns of subtracted sets are not recorded.
+func (s *GlobSet) WithStats() *GlobSet {
+	set := *s
+	set.stats = make([]patternCounters, len(s.globs))
+	return &set
+}
+
+// Stats returns the statistics of the patterns of a set returned by
+// WithStats, in the order of the patterns. It returns nil for other sets.
+func (s *GlobSet) Stats() []PatternStats {
+	if s.stats == nil {
+		return nil
+	}
+	stats := make([]PatternStats, len(s.globs))
+	for i, g := range s.globs {
+		stats[i] = PatternStats{
+			Pattern:   g.String(),
+			Evaluated: s.stats[i].evaluated.Load(),
+			Matched:   s.stats[i].matched.Load(),
+		}
+	}
+	return stats
+}
+
+// WithStats returns a set ignoring the same paths as s, which records how
+// many paths each of its rules is tested against and decides, as reported by
+// Stats. Since the last matching rule decides, rules are tested from the
+// last one, until one matches: a rule counted as evaluated but never matched
+// was always overridden, or never applied. The set s itself does not record
+// anything.
+func (s *IgnoreSet) WithStats() *IgnoreSet {
+	set := *s
+	set.stats = make([]patternCounters, len(s.rules))
+	return &set
+}
+
+// Stats returns the statistics of the rules of a set returned by WithStats,
+// in the order of the rules. It returns nil for other sets.
+func (s *IgnoreSet) Stats() []PatternStats {
+	if s.stats == nil {
+		return nil
+	}
+	stats := make([]PatternStats, len(s.rules))
+	for i, rule := range s.rules {
+		pattern := rule.glob.String()
+		if rule.reinclude {
+			pattern = "!" + pattern
+		}
+		stats[i] = PatternStats{
+			Pattern:   pattern,
+			Evaluated: s.stats[i].evaluated.Load(),
+			Matched:   s.stats[i].matched.Load(),
+		}
+	}
+	return stats
+}
@@ -0,0 +1,75 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGlobSetStats(t *testing.T) {
+	base := MustCompileGlobSet([]string{"*.go", "docs/**", "*.md"})
+	set := base.WithStats()
+
+	for _, file := range []string{"main.go", "docs/x", "a.c", "b.go"} {
+		set.Match(file)
+	}
+	expected := []PatternStats{
+		{Pattern: "*.go", Evaluated: 4, Matched: 2},
+		{Pattern: "docs/**", Evaluated: 4, Matched: 1},
+		{Pattern: "*.md", Evaluated: 4, Matched: 0},
+	}
+	if actual := set.Stats(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+
+	if stats := base.Stats(); stats != nil {
+		t.Fatalf("expected no stats for the original set, got %v", stats)
+	}
+
+	// Subtracting keeps recording, and still excludes the matches.
+	sub := set.Subtract(MustCompileGlobSet([]string{"*_test.go"}))
+	if sub.Match("a_test.go") {
+		t.Fatal("expected a_test.go to be subtracted")
+	}
+	if actual := set.Stats()[0]; actual.Evaluated != 5 || actual.Matched != 3 {
+		t.Fatalf("expected 5 evaluations and 3 matches, got %v", actual)
+	}
+}
+
+func TestIgnoreSetStats(t *testing.T) {
+	set, err := CompileIgnoreSet([]string{"*.log", "build/**", "!keep.log", "*.tmp"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	set = set.WithStats()
+
+	for _, path := range []string{"a.log", "keep.log", "build/x", "src/a.go"} {
+		set.Match(path)
+	}
+	stats := set.Stats()
+	tcases := []struct {
+		Pattern   string
+		Evaluated int64
+		Matched   int64
+	}{
+		{"", 2, 1},
+		{"", 3, 1},
+		{"!", 4, 1},
+		{"", 4, 0},
+	}
+	if len(stats) != len(tcases) {
+		t.Fatalf("expected %d rules, got %d", len(tcases), len(stats))
+	}
+	for i, tc := range tcases {
+		if stats[i].Evaluated != tc.Evaluated || stats[i].Matched != tc.Matched {
+			t.Fatalf("rule %d: expected %d/%d, got %v", i, tc.Evaluated, tc.Matched, stats[i])
+		}
+		if (stats[i].Pattern[0] == '!') != (tc.Pattern == "!") {
+			t.Fatalf("rule %d: unexpected pattern %q", i, stats[i].Pattern)
+		}
+	}
+}
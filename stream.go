@@ -0,0 +1,147 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"bufio"
+	"io"
+	"iter"
+	"strings"
+)
+
+// A LineOption alters the way MatchLines and FilterLines split and trim
+// lines.
+type LineOption func(*lineConfig)
+
+type lineConfig struct {
+	delim     byte
+	trimSpace bool
+	prefix    string
+}
+
+// LineDelimiter sets the byte terminating lines. It defaults to '\n', in
+// which case a "\r" preceding it is also removed. Use 0 for NUL-terminated
+// paths, as with MatchNul.
+func LineDelimiter(delim byte) LineOption {
+	return func(cfg *lineConfig) {
+		cfg.delim = delim
+	}
+}
+
+// LineTrimSpace removes the leading and trailing white space of lines,
+// as defined by Unicode, before matching them.
+func LineTrimSpace() LineOption {
+	return func(cfg *lineConfig) {
+		cfg.trimSpace = true
+	}
+}
+
+// LineTrimPrefix removes prefix from the start of the lines starting with
+// it, after trimming white space, before matching them. For instance,
+// LineTrimPrefix("./") allows matching the paths printed by "find ." with
+// patterns relative to the current directory.
+func LineTrimPrefix(prefix string) LineOption {
+	return func(cfg *lineConfig) {
+		cfg.prefix = prefix
+	}
+}
+
+// MatchLines returns an iterator over the lines read from r that match m,
+// which is typically a *Glob or a *GlobSet, as a grep(1) of globs would. This
+// allows filtering manifests or the output of commands like "ls" or "git
+// ls-files". The last line may lack its terminator. Lines are yielded
+// without their terminator, once trimmed as set by opts. If reading r fails,
+// the error is yielded with an empty line, and the iteration stops.
+func MatchLines(r io.Reader, m Matcher, opts ...LineOption) iter.Seq2[string, error] {
+	cfg := lineConfig{delim: '\n'}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return matchDelimited(r, m, cfg)
+}
+
+// FilterLines copies the lines read from r that match m to w, each followed
+// by the delimiter of lines. See MatchLines for details.
+func FilterLines(w io.Writer, r io.Reader, m Matcher, opts ...LineOption) error {
+	cfg := lineConfig{delim: '\n'}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return filterDelimited(w, r, m, cfg)
+}
+
+// MatchNul returns an iterator over the NUL-terminated paths read from r
+// that match m, which is typically a *Glob or a *GlobSet. This allows
+// filtering the output of commands like "find -print0" or "git ls-files -z".
+// The last path may lack its terminator. If reading r fails, the error is
+// yielded with an empty path, and the iteration stops.
+//
+// Paths are matched as read: to match the "./"-prefixed paths printed by
+// "find .", compile the patterns with the CleanPath option.
+func MatchNul(r io.Reader, m Matcher) iter.Seq2[string, error] {
+	return matchDelimited(r, m, lineConfig{})
+}
+
+// FilterNul copies the NUL-terminated paths read from r that match m to w,
+// each followed by a NUL byte, so that the output can be passed to commands
+// like "xargs -0". See MatchNul for details.
+func FilterNul(w io.Writer, r io.Reader, m Matcher) error {
+	return filterDelimited(w, r, m, lineConfig{})
+}
+
+// matchDelimited returns an iterator over the paths read from r, split and
+// trimmed as set by cfg, that match m.
+func matchDelimited(r io.Reader, m Matcher, cfg lineConfig) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		br := bufio.NewReader(r)
+		for {
+			path, err := br.ReadString(cfg.delim)
+			if len(path) > 0 && path[len(path)-1] == cfg.delim {
+				path = path[:len(path)-1]
+			} else if err == io.EOF && path == "" {
+				return
+			}
+			if err != nil && err != io.EOF {
+				yield("", err)
+				return
+			}
+			path = cfg.trim(path)
+			if m.Match(path) && !yield(path, nil) {
+				return
+			}
+			if err == io.EOF {
+				return
+			}
+		}
+	}
+}
+
+// trim returns path trimmed as set by cfg.
+func (cfg lineConfig) trim(path string) string {
+	if cfg.delim == '\n' {
+		path = strings.TrimSuffix(path, "\r")
+	}
+	if cfg.trimSpace {
+		path = strings.TrimSpace(path)
+	}
+	return strings.TrimPrefix(path, cfg.prefix)
+}
+
+// filterDelimited copies the paths read from r, split and trimmed as set by
+// cfg, that match m to w, each followed by the delimiter of cfg.
+func filterDelimited(w io.Writer, r io.Reader, m Matcher, cfg lineConfig) error {
+	bw := bufio.NewWriter(w)
+	for path, err := range matchDelimited(r, m, cfg) {
+		if err != nil {
+			return err
+		}
+		bw.WriteString(path)
+		if err := bw.WriteByte(cfg.delim); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,110 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestMatchNul(t *testing.T) {
+	tcases := []struct {
+		Input    string
+		Expected []string
+	}{
+		{"", nil},
+		{"a.go\x00b.md\x00c.go\x00", []string{"a.go", "c.go"}},
+		{"a.go\x00b.md\x00c.go", []string{"a.go", "c.go"}},
+		{"\x00x y.go\x00dir/z.go\x00", []string{"x y.go"}},
+		{"new\nline.go\x00", []string{"new\nline.go"}},
+	}
+
+	g := MustCompileGlob("*.go")
+	for _, tc := range tcases {
+		t.Run(tc.Input, func(t *testing.T) {
+			var actual []string
+			for path, err := range MatchNul(strings.NewReader(tc.Input), g) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				actual = append(actual, path)
+			}
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+
+			var out strings.Builder
+			if err := FilterNul(&out, strings.NewReader(tc.Input), g); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var expected string
+			for _, path := range tc.Expected {
+				expected += path + "\x00"
+			}
+			if out.String() != expected {
+				t.Fatalf("expected output %q, got %q", expected, out.String())
+			}
+		})
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		errRead := errors.New("read error")
+		r := iotest.DataErrReader(iotest.ErrReader(errRead))
+		for path, err := range MatchNul(r, g) {
+			if err != errRead || path != "" {
+				t.Fatalf("expected %v, got %q, %v", errRead, path, err)
+			}
+		}
+		if err := FilterNul(new(strings.Builder), iotest.ErrReader(errRead), g); err != errRead {
+			t.Fatalf("expected %v, got %v", errRead, err)
+		}
+	})
+}
+
+func TestMatchLines(t *testing.T) {
+	tcases := []struct {
+		Input    string
+		Options  []LineOption
+		Expected []string
+	}{
+		{"", nil, nil},
+		{"a.go\nb.md\nc.go\n", nil, []string{"a.go", "c.go"}},
+		{"a.go\r\nb.md\r\nc.go", nil, []string{"a.go", "c.go"}},
+		{"  a.go \n\tc.go\n", nil, []string{"\tc.go"}},
+		{"  a.go \n\tc.go\n", []LineOption{LineTrimSpace()}, []string{"a.go", "c.go"}},
+		{"./a.go\n./dir/b.go\nc.go\n", []LineOption{LineTrimPrefix("./")}, []string{"a.go", "c.go"}},
+		{" ./a.go\n", []LineOption{LineTrimSpace(), LineTrimPrefix("./")}, []string{"a.go"}},
+		{"a.go\x00b\nc.go\x00", []LineOption{LineDelimiter(0)}, []string{"a.go", "b\nc.go"}},
+		{"a.go:b.md:c.go", []LineOption{LineDelimiter(':')}, []string{"a.go", "c.go"}},
+	}
+
+	g := MustCompileGlob("*.go")
+	for _, tc := range tcases {
+		t.Run(tc.Input, func(t *testing.T) {
+			var actual []string
+			for line, err := range MatchLines(strings.NewReader(tc.Input), g, tc.Options...) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				actual = append(actual, line)
+			}
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+		})
+	}
+
+	var out strings.Builder
+	if err := FilterLines(&out, strings.NewReader("a.go:b.md:c.go"), g, LineDelimiter(':')); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "a.go:c.go:"; out.String() != expected {
+		t.Fatalf("expected output %q, got %q", expected, out.String())
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type structVariableMap struct {
+	v reflect.Value
+}
+
+// StructVars returns a VariableMap exposing the exported fields of the
+// struct v as variables. v must be a struct or a pointer to a struct, or
+// StructVars panics.
+//
+// Variables are named after their field, unless the field has a "shutil" tag,
+// in which case the tag value is used as the variable name. Fields tagged
+// with `shutil:"-"` are ignored. The fields of embedded structs are accessible
+// as if they were fields of v.
+//
+// Fields of nested structs, and values of maps with string keys, are
+// accessed with dots: with v being a struct whose Server field holds a struct
+// with a Port field, ${Server.Port} expands to the value of the Port field.
+//
+// Values are formatted with fmt.Sprint. Nil pointers and interfaces are
+// treated as undefined variables.
+func StructVars(v interface{}) VariableMap {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("shutil: StructVars called with non-struct type %T", v))
+	}
+	return structVariableMap{v: rv}
+}
+
+func (smap structVariableMap) Get(variable string) (string, bool) {
+	v := smap.v
+	for _, name := range strings.Split(variable, ".") {
+		var ok bool
+		if v, ok = indirect(v); !ok {
+			return "", false
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			v, ok = structField(v, name)
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return "", false
+			}
+			v = v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			ok = v.IsValid()
+		default:
+			return "", false
+		}
+		if !ok {
+			return "", false
+		}
+	}
+	v, ok := indirect(v)
+	if !ok || !v.CanInterface() {
+		return "", false
+	}
+	return fmt.Sprint(v.Interface()), true
+}
+
+// indirect dereferences pointers and interfaces until reaching a concrete
+// value. It returns false if it encounters a nil value.
+func indirect(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, v.IsValid()
+}
+
+// structField returns the exported field of v named name, looking into
+// embedded structs.
+func structField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("shutil")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && tag == "" {
+			fv, ok := indirect(v.Field(i))
+			if ok && fv.Kind() == reflect.Struct {
+				if fv, ok := structField(fv, name); ok {
+					return fv, true
+				}
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			// unexported field
+			continue
+		}
+		if tag == "" {
+			tag = field.Name
+		}
+		if tag == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,64 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"testing"
+)
+
+func TestStructVars(t *testing.T) {
+	type Server struct {
+		Host string
+		Port int `shutil:"port"`
+	}
+	type Common struct {
+		Env string
+	}
+	type Config struct {
+		Common
+		Name    string
+		Server  Server `shutil:"server"`
+		Backup  *Server
+		Labels  map[string]string
+		Secret  string `shutil:"-"`
+		private string
+	}
+
+	vars := StructVars(&Config{
+		Common: Common{Env: "prod"},
+		Name:   "api",
+		Server: Server{Host: "localhost", Port: 8080},
+		Labels: map[string]string{"team": "infra"},
+		Secret: "hunter2",
+	})
+
+	tcases := []struct {
+		In, Expected string
+	}{
+		{`${Name}`, "api"},
+		{`${Env}`, "prod"},
+		{`${server.Host}:${server.port}`, "localhost:8080"},
+		{`${Labels.team}`, "infra"},
+		{`${Backup.Host:-none}`, "none"},
+		{`${Labels.owner:-nobody}`, "nobody"},
+		{`${Secret:-hidden}`, "hidden"},
+		{`${private:-hidden}`, "hidden"},
+		{`${Server.Host:-renamed}`, "renamed"},
+		{`${Name.Length:-scalar}`, "scalar"},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.In, func(t *testing.T) {
+			actual, err := Substitute(tc.In, vars)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != tc.Expected {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
@@ -48,6 +48,13 @@ var reGroup = regexp.MustCompile(`\\([0-9]+)`)
 //    for instance, ${variable/^([^:]*):/\1/}, where variable=foo:bar, expands
 //    to foo.
 func Substitute(s string, vars VariableMap) (string, error) {
+	return substitute(s, vars, nil)
+}
+
+// substitute is like Substitute, but passes the values derived from the
+// variables of vars through quote, if not nil, before substituting them.
+// Default and alternate values, which are part of s, are substituted as is.
+func substitute(s string, vars VariableMap, quote func(string) string) (string, error) {
 	var out strings.Builder
 	start := 0
 outer:
@@ -106,6 +113,7 @@ outer:
 
 			out.WriteString(s[start:subsStart])
 			value, present := vars.Get(name)
+			inline := false
 
 			if def == nil {
 				if !present {
@@ -119,11 +127,11 @@ outer:
 				switch deref[0] {
 				case '-':
 					if !present {
-						value = deref[1:]
+						value, inline = deref[1:], true
 					}
 				case '+':
 					if present {
-						value = deref[1:]
+						value, inline = deref[1:], true
 					}
 				case '/':
 					// This is a regexp substitution
@@ -151,6 +159,9 @@ outer:
 				}
 			}
 
+			if quote != nil && !inline {
+				value = quote(value)
+			}
 			out.WriteString(value)
 
 			i += delim + 1
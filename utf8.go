@@ -0,0 +1,73 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// UTF8Mode sets how globs treat the bytes that are not valid UTF-8, in
+// patterns as well as in the strings matched. File names on Linux are
+// arbitrary bytes, which other systems may have written in another encoding.
+type UTF8Mode int
+
+const (
+	// UTF8Replace decodes each invalid byte as utf8.RuneError, as ranging
+	// over a string does. An invalid byte then matches any other invalid
+	// byte, as well as "\uFFFD". This is the default.
+	UTF8Replace UTF8Mode = iota
+
+	// UTF8Bytes matches invalid bytes as raw bytes: an invalid byte of the
+	// pattern only matches the same byte. Wildcards, and negated bracket
+	// expressions, match any invalid byte, while other bracket expressions
+	// match none, unless they list it.
+	UTF8Bytes
+
+	// UTF8Strict rejects invalid bytes: compiling a pattern containing some
+	// fails with ErrInvalidUTF8, and strings containing some never match.
+	UTF8Strict
+)
+
+// Under UTF8Bytes, invalid bytes are decoded as runes past utf8.MaxRune,
+// which valid UTF-8 never decodes to, starting at rawBytes for byte 0.
+const (
+	rawBytes    = utf8.MaxRune + 1
+	rawBytesEnd = rawBytes + 0x100
+)
+
+// rawBytes returns whether the pattern of g has invalid bytes matched as raw
+// bytes, which translations to other pattern languages cannot express.
+func (g *Glob) rawBytes() bool {
+	return g.opts.InvalidUTF8 == UTF8Bytes && !utf8.ValidString(g.pattern)
+}
+
+// decodeRune is like utf8.DecodeRuneInString, but decodes invalid bytes as
+// specified by mode. s must not be empty.
+func decodeRune(s string, mode UTF8Mode) (rune, int) {
+	r, n := utf8.DecodeRuneInString(s)
+	if mode == UTF8Bytes && r == utf8.RuneError && n == 1 {
+		r = rawBytes + rune(s[0])
+	}
+	return r, n
+}
+
+// writeRune writes r to b, writing the runes of invalid bytes decoded under
+// UTF8Bytes as the bytes themselves.
+func writeRune(b *strings.Builder, r rune) {
+	if r >= rawBytes && r < rawBytesEnd {
+		b.WriteByte(byte(r - rawBytes))
+		return
+	}
+	b.WriteRune(r)
+}
+
+// encodeRune returns r as a string, as written by writeRune.
+func encodeRune(r rune) string {
+	var b strings.Builder
+	writeRune(&b, r)
+	return b.String()
+}
@@ -0,0 +1,109 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGlobInvalidUTF8(t *testing.T) {
+	tcases := []struct {
+		Pattern string
+		Mode    UTF8Mode
+		Input   string
+		Match   bool
+	}{
+		{"caf\xe9", UTF8Replace, "caf\xe9", true},
+		{"caf\xe9", UTF8Replace, "caf\xe8", true},
+		{"caf\xe9", UTF8Replace, "caf�", true},
+		{"*.txt", UTF8Replace, "\xff.txt", true},
+
+		{"caf\xe9", UTF8Bytes, "caf\xe9", true},
+		{"caf\xe9", UTF8Bytes, "caf\xe8", false},
+		{"caf\xe9", UTF8Bytes, "caf�", false},
+		{"caf�", UTF8Bytes, "caf\xe9", false},
+		{"caf?", UTF8Bytes, "caf\xe9", true},
+		{"caf*", UTF8Bytes, "caf\xe9\xe8", true},
+		{"*.txt", UTF8Bytes, "\xff.txt", true},
+		{"[!a]*", UTF8Bytes, "\xff", true},
+		{"[a-z]", UTF8Bytes, "\xff", false},
+		{"[\xfe\xff]", UTF8Bytes, "\xff", true},
+		{"[\xfe\xff]", UTF8Bytes, "\xfd", false},
+		{"{caf\xe9,tea}/*", UTF8Bytes, "caf\xe9/x", true},
+		{"**/caf\xe9", UTF8Bytes, "a/b/caf\xe9", true},
+		{"caf\xe9", UTF8Bytes, "CAF\xe9", false},
+
+		{"*.txt", UTF8Strict, "notes.txt", true},
+		{"*.txt", UTF8Strict, "\xff.txt", false},
+		{"**", UTF8Strict, "a/\xff/b", false},
+		{"café", UTF8Strict, "café", true},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			opts := GlobOptions{InvalidUTF8: tc.Mode}
+			g, err := CompileGlobOptions(tc.Pattern, opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if match := g.Match(tc.Input); match != tc.Match {
+				t.Fatalf("%q: expected %v, got %v", tc.Input, tc.Match, match)
+			}
+			if match := g.MatchBytes([]byte(tc.Input)); match != tc.Match {
+				t.Fatalf("%q: expected MatchBytes to return %v, got %v", tc.Input, tc.Match, match)
+			}
+			set := NewGlobSet([]*Glob{MustCompileGlob("none"), g})
+			if match := set.Match(tc.Input); match != tc.Match {
+				t.Fatalf("%q: expected set to return %v, got %v", tc.Input, tc.Match, match)
+			}
+		})
+	}
+
+	t.Run("Strict", func(t *testing.T) {
+		_, err := CompileGlobOptions("ab\xffc", GlobOptions{InvalidUTF8: UTF8Strict})
+		var gerr *GlobError
+		if !errors.As(err, &gerr) || !errors.Is(err, ErrInvalidUTF8) || gerr.Index != 2 {
+			t.Fatalf("expected ErrInvalidUTF8 at index 2, got %v", err)
+		}
+		g, _ := CompileGlobOptions("src/**", GlobOptions{InvalidUTF8: UTF8Strict})
+		if g.MatchPrefix("src/\xff") || g.MatchDirPrefix("src/\xff") {
+			t.Fatalf("expected invalid UTF-8 not to match prefixes")
+		}
+	})
+
+	t.Run("Bytes", func(t *testing.T) {
+		g, _ := CompileGlobOptions("caf\xe9/*.{c,h}", GlobOptions{InvalidUTF8: UTF8Bytes})
+		if prefix := g.Prefix(); prefix != "caf\xe9/" {
+			t.Errorf("expected prefix %q, got %q", "caf\xe9/", prefix)
+		}
+		if captures, ok := g.Capture("caf\xe9/x\xff.c"); !ok || !reflect.DeepEqual(captures, []string{"x\xff", "c"}) {
+			t.Errorf("expected captures %q, got %q", []string{"x\xff", "c"}, captures)
+		}
+		if !g.MatchDirPrefix("caf\xe9") || g.MatchDirPrefix("caf\xe8") {
+			t.Errorf("expected only %q to be a viable directory", "caf\xe9")
+		}
+		if example, ok := g.Example(); !ok || example != "caf\xe9/a.c" {
+			t.Errorf("expected example %q, got %q", "caf\xe9/a.c", example)
+		}
+		lit, _ := CompileGlobOptions("\xff", GlobOptions{InvalidUTF8: UTF8Bytes})
+		if literal, ok := lit.Literal(); !ok || literal != "\xff" {
+			t.Errorf("expected literal %q, got %q", "\xff", literal)
+		}
+		if _, err := g.SQLLike('\\'); !errors.Is(err, ErrUntranslatable) {
+			t.Errorf("expected ErrUntranslatable, got %v", err)
+		}
+		other, _ := CompileGlobOptions("*", GlobOptions{InvalidUTF8: UTF8Bytes})
+		if witness, ok := GlobOverlap(g, other); ok {
+			t.Errorf("expected no overlap, got %q", witness)
+		}
+		other, _ = CompileGlobOptions("[!a]*/*", GlobOptions{InvalidUTF8: UTF8Bytes})
+		if witness, ok := GlobOverlap(g, other); !ok || !g.Match(witness) || !other.Match(witness) {
+			t.Errorf("expected an overlap, got (%q, %v)", witness, ok)
+		}
+	})
+}
@@ -0,0 +1,775 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type walkConfig struct {
+	workers  int
+	maxDepth int
+	symlinks SymlinkMode
+	types    EntryType
+	onError  func(path string, err error) error
+	order    SortOrder
+	limit    int
+	onMatch  func(path string) error
+	prune    func(dir string) bool
+
+	ignoreFiles []string
+}
+
+// A WalkOption alters the behaviour of Glob.Walk and Glob.WalkContext.
+type WalkOption func(*walkConfig)
+
+// WalkWorkers sets the number of directories read concurrently. It defaults
+// to runtime.GOMAXPROCS(0). The order of the results does not depend on it.
+func WalkWorkers(n int) WalkOption {
+	return func(cfg *walkConfig) {
+		cfg.workers = n
+	}
+}
+
+// WalkMaxDepth limits the walk to the paths at most n directory levels below
+// the root of the filesystem: with n = 1, only the entries of the root are
+// matched, and no subdirectory is read. This bounds the recursion of "**"
+// in very deep trees. A zero or negative n, the default, means no limit.
+func WalkMaxDepth(n int) WalkOption {
+	return func(cfg *walkConfig) {
+		cfg.maxDepth = n
+	}
+}
+
+// SymlinkMode sets how walks treat symbolic links.
+type SymlinkMode int
+
+const (
+	// SymlinksAsEntries reports symbolic links as entries of their own,
+	// matched like files whatever they point to, and never followed. This
+	// is the default, and suits tools like backups that preserve links.
+	SymlinksAsEntries SymlinkMode = iota
+
+	// SymlinksFollow resolves symbolic links: links to directories are
+	// matched and walked as directories, and other links are matched like
+	// what they point to. Links that cannot be resolved are reported as
+	// entries of their own. Links to a directory being walked, which would
+	// cause a cycle, are matched but not walked again.
+	//
+	// Cycles are detected with os.SameFile, which requires fsys to return
+	// file information from the os package, as os.DirFS does. With other
+	// filesystems, use WalkMaxDepth to bound walks.
+	SymlinksFollow
+)
+
+// WalkSymlinks sets how symbolic links are treated. It defaults to
+// SymlinksAsEntries.
+func WalkSymlinks(mode SymlinkMode) WalkOption {
+	return func(cfg *walkConfig) {
+		cfg.symlinks = mode
+	}
+}
+
+// EntryType is a set of types of directory entries.
+type EntryType uint8
+
+const (
+	// TypeFile is the type of regular files.
+	TypeFile EntryType = 1 << iota
+
+	// TypeDir is the type of directories.
+	TypeDir
+
+	// TypeSymlink is the type of symbolic links. When following symbolic
+	// links, only the links that cannot be resolved have this type, and
+	// the others have the type of their target.
+	TypeSymlink
+
+	// TypeOther is the type of all other entries, like devices, named pipes
+	// and sockets.
+	TypeOther
+)
+
+// includes returns whether the set includes the fs.FileMode type typ. The
+// empty set includes all types.
+func (t EntryType) includes(typ fs.FileMode) bool {
+	if t == 0 {
+		return true
+	}
+	switch {
+	case typ.IsRegular():
+		return t&TypeFile != 0
+	case typ.IsDir():
+		return t&TypeDir != 0
+	case typ&fs.ModeSymlink != 0:
+		return t&TypeSymlink != 0
+	}
+	return t&TypeOther != 0
+}
+
+// WalkTypes restricts the results of the walk to the entries of the
+// specified types, as in WalkTypes(TypeFile|TypeSymlink). Types are known
+// from the directories read, without additional calls to fs.Stat. By
+// default, entries of all types are returned.
+//
+// Only the results are restricted: directories are still walked.
+func WalkTypes(types EntryType) WalkOption {
+	return func(cfg *walkConfig) {
+		cfg.types = types
+	}
+}
+
+// WalkOnError sets a function handling the errors reading directories and
+// their entries, path being the path of the directory or entry. If fn
+// returns nil, the directory or entry is skipped, and the walk goes on.
+// Otherwise, the walk stops and returns the error fn returned. For instance,
+// fn can log and skip permission errors.
+//
+// Calls to fn are serialized, even though directories are read concurrently.
+// By default, any error stops the walk.
+func WalkOnError(fn func(path string, err error) error) WalkOption {
+	return func(cfg *walkConfig) {
+		cfg.onError = fn
+	}
+}
+
+// WalkLimit stops the walk once n paths matched, and returns them. Which
+// paths are found first depends on the order directories are read in, and
+// is only reproducible with WalkWorkers(1). WalkLimit(1) allows checking
+// whether anything matches without walking the whole tree. A zero or
+// negative n, the default, means no limit.
+func WalkLimit(n int) WalkOption {
+	return func(cfg *walkConfig) {
+		cfg.limit = n
+	}
+}
+
+// WalkOnMatch sets a function called with each matching path, as soon as it
+// is found rather than in the order of the results. If fn returns
+// fs.SkipAll, the walk stops and returns the paths found so far, fn's path
+// included. If fn returns another error, the walk stops and returns the
+// error.
+//
+// Calls to fn are serialized, even though directories are read concurrently.
+func WalkOnMatch(fn func(path string) error) WalkOption {
+	return func(cfg *walkConfig) {
+		cfg.onMatch = fn
+	}
+}
+
+// WalkPrune sets a function called with the path of each directory found,
+// whose contents are not walked if fn returns true. The directory itself is
+// still matched. This allows skipping the directories excluded by other
+// rules, such as the ones an IgnoreSet ignores, or "**/node_modules/**":
+//
+//	WalkPrune(func(dir string) bool { return excluded.Match(dir + "/") })
+//
+// Regardless of fn, walks skip the directories under which no path can
+// match the pattern. Calls to fn are serialized.
+func WalkPrune(fn func(dir string) bool) WalkOption {
+	return func(cfg *walkConfig) {
+		cfg.prune = fn
+	}
+}
+
+// SortOrder sets the order of the results of walks.
+type SortOrder int
+
+const (
+	// SortTree orders paths the way fs.WalkDir visits them: component by
+	// component, in lexical order, a directory coming before its contents.
+	// This is the default.
+	SortTree SortOrder = iota
+
+	// SortLexical orders paths as strings, byte by byte. Unlike SortTree,
+	// it puts "a-b" before "a/b".
+	SortLexical
+
+	// SortDirsFirst orders paths like SortTree, except that in each
+	// directory, subdirectories and their contents come before the other
+	// entries.
+	SortDirsFirst
+
+	// SortPatterns orders the results of GlobSet walks by the index of the
+	// first pattern they match, and then like SortTree. For Glob walks, it
+	// is the same as SortTree.
+	SortPatterns
+)
+
+// WalkSort sets the order of the results. It defaults to SortTree. Results
+// are always sorted, whatever the order directories are read in, which
+// makes walks reproducible.
+func WalkSort(order SortOrder) WalkOption {
+	return func(cfg *walkConfig) {
+		cfg.order = order
+	}
+}
+
+// Walk walks fsys and returns the paths of all files and directories that
+// match the glob pattern, in the order set by WalkSort.
+//
+// Paths are matched the same way as MatchInfo: a directory also matches if
+// its path followed by "/" matches, so "**/" returns all directories. The
+// returned paths are fs.FS paths, without trailing slash, and never include
+// the root "." itself.
+//
+// Only the directory designated by the prefix of the pattern (see Prefix) is
+// walked, and the directories under which no path can match are skipped.
+// Subdirectories are read concurrently, as set by WalkWorkers. Symbolic
+// links are not followed unless set otherwise by WalkSymlinks.
+func (g *Glob) Walk(fsys fs.FS, opts ...WalkOption) ([]string, error) {
+	return g.WalkContext(context.Background(), fsys, opts...)
+}
+
+// WalkContext is like Walk, but stops walking and returns the error of ctx
+// once ctx is done.
+func (g *Glob) WalkContext(ctx context.Context, fsys fs.FS, opts ...WalkOption) ([]string, error) {
+	index := func(path string) int {
+		if g.Match(path) {
+			return 0
+		}
+		return -1
+	}
+	return walk(ctx, fsys, walkPattern{
+		root:    g.walkRoot(),
+		dirOnly: g.DirOnly(),
+		index:   index,
+		under:   g.canMatchUnder,
+	}, opts)
+}
+
+// Walk walks fsys and returns the paths of all files and directories that
+// match at least one pattern of the set, as Glob.Walk does. Only the
+// directory all the prefixes of the patterns have in common is walked.
+func (s *GlobSet) Walk(fsys fs.FS, opts ...WalkOption) ([]string, error) {
+	return s.WalkContext(context.Background(), fsys, opts...)
+}
+
+// WalkContext is like Walk, but stops walking and returns the error of ctx
+// once ctx is done.
+func (s *GlobSet) WalkContext(ctx context.Context, fsys fs.FS, opts ...WalkOption) ([]string, error) {
+	if len(s.globs) == 0 {
+		return nil, nil
+	}
+	return walk(ctx, fsys, s.walkPattern(), opts)
+}
+
+// walkPattern returns the pattern of the walks of the set, which must not be
+// empty.
+func (s *GlobSet) walkPattern() walkPattern {
+	pat := walkPattern{
+		dirOnly: true,
+		index:   s.matchIndex,
+		under:   s.canMatchUnder,
+	}
+	for _, g := range s.globs {
+		if g.negated {
+			// Negated globs only exclude paths the others match.
+			continue
+		}
+		if pat.root == "" {
+			pat.root = g.walkRoot()
+		}
+		pat.root = commonDir(pat.root, g.walkRoot())
+		pat.dirOnly = pat.dirOnly && g.DirOnly()
+	}
+	if pat.root == "" {
+		pat.root = "."
+	}
+	return pat
+}
+
+// walkRoot returns the directory all the matches of the glob are under, or
+// the path of the only match of literal globs.
+func (g *Glob) walkRoot() string {
+	if prefix := strings.TrimSuffix(g.Prefix(), "/"); prefix != "" && !g.opts.CaseInsensitive && g.opts.Separator == 0 {
+		return prefix
+	}
+	return "."
+}
+
+// commonDir returns the longest path both paths are under.
+func commonDir(a, b string) string {
+	for {
+		switch {
+		case a == b:
+			return a
+		case strings.HasPrefix(b, a+"/"):
+			return a
+		case a == "." || b == ".":
+			return "."
+		}
+		if len(a) > len(b) {
+			a = path.Dir(a)
+		} else {
+			b = path.Dir(b)
+		}
+	}
+}
+
+// A SetMatch is a path found by GlobSet.WalkIndices, with the indices of
+// all the patterns of the set it matches, in increasing order.
+type SetMatch struct {
+	Path    string
+	Indices []int
+}
+
+// WalkIndices is like Walk, but also reports which patterns each path
+// matches. As with Walk, each path is reported once, whatever the number of
+// patterns it matches. Like MatchEntry, the indices of directories include
+// those of the patterns matching their path followed by "/".
+func (s *GlobSet) WalkIndices(fsys fs.FS, opts ...WalkOption) ([]SetMatch, error) {
+	return s.WalkIndicesContext(context.Background(), fsys, opts...)
+}
+
+// WalkIndicesContext is like WalkIndices, but stops walking and returns the
+// error of ctx once ctx is done.
+func (s *GlobSet) WalkIndicesContext(ctx context.Context, fsys fs.FS, opts ...WalkOption) ([]SetMatch, error) {
+	if len(s.globs) == 0 {
+		return nil, nil
+	}
+	matches, err := walkMatches(ctx, fsys, s.walkPattern(), opts)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]SetMatch, len(matches))
+	for i, m := range matches {
+		indices := s.MatchIndices(m.path)
+		if m.dir {
+			for _, j := range s.MatchIndices(m.path + "/") {
+				if !slices.Contains(indices, j) {
+					indices = append(indices, j)
+				}
+			}
+			slices.Sort(indices)
+		}
+		results[i] = SetMatch{Path: m.path, Indices: indices}
+	}
+	return results, nil
+}
+
+// walkPattern describes the paths a walk matches.
+type walkPattern struct {
+	// root is the directory all matches are under.
+	root string
+
+	// dirOnly is set if files cannot match, and need not be tested.
+	dirOnly bool
+
+	// index returns the index of the pattern path matches, or -1.
+	index func(path string) int
+
+	// under returns whether paths under dir may match.
+	under func(dir string) bool
+}
+
+// walk walks the directory pat.root of fsys, and returns the paths matching
+// pat.
+func walk(ctx context.Context, fsys fs.FS, pat walkPattern, opts []WalkOption) ([]string, error) {
+	matches, err := walkMatches(ctx, fsys, pat, opts)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, m := range matches {
+		paths = append(paths, m.path)
+	}
+	return paths, nil
+}
+
+// walkMatches is like walk, but returns the matches themselves.
+func walkMatches(ctx context.Context, fsys fs.FS, pat walkPattern, opts []WalkOption) ([]walkMatch, error) {
+	root := pat.root
+	cfg := walkConfig{workers: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.workers < 1 {
+		cfg.workers = 1
+	}
+	if !fs.ValidPath(root) {
+		return nil, nil
+	}
+
+	w := walker{
+		ctx:      ctx,
+		fsys:     fsys,
+		index:    pat.index,
+		under:    pat.under,
+		prune:    cfg.prune,
+		dirOnly:  pat.dirOnly,
+		maxDepth: cfg.maxDepth,
+		follow:   cfg.symlinks == SymlinksFollow,
+		types:    cfg.types,
+		onError:  cfg.onError,
+		limit:    cfg.limit,
+		onMatch:  cfg.onMatch,
+
+		ignoreFiles: cfg.ignoreFiles,
+		metrics:     currentMetrics(),
+	}
+	w.cond.L = &w.mu
+
+	var ignores *ignoreChain
+	if len(w.ignoreFiles) != 0 {
+		var ignored bool
+		var err error
+		if ignores, ignored, err = w.rootIgnores(root); err != nil || ignored {
+			return nil, err
+		}
+	}
+
+	if root == "." {
+		w.queue = []walkDir{{path: root, ignores: ignores}}
+	} else if w.tooDeep(root) {
+		return nil, nil
+	} else {
+		info, err := fs.Stat(fsys, root)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			return nil, nil
+		case err != nil:
+			return nil, w.handle(root, err)
+		}
+		if ignores.ignored(root, info.IsDir()) {
+			return nil, nil
+		}
+		w.visit(root, info.Mode().Type())
+		if info.IsDir() && !w.atMaxDepth(root) {
+			w.queue = []walkDir{{path: root, ignores: ignores}}
+		}
+	}
+	if w.follow && len(w.queue) != 0 {
+		// Record the root and the directories leading to it, to detect links
+		// to them.
+		for dir := root; ; dir = path.Dir(dir) {
+			info, err := fs.Stat(fsys, dir)
+			if err != nil {
+				return nil, err
+			}
+			w.queue[0].ancestors = append(w.queue[0].ancestors, info)
+			if dir == "." {
+				break
+			}
+		}
+	}
+	w.pending = len(w.queue)
+
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.work()
+		}()
+	}
+	wg.Wait()
+
+	if w.err != nil && w.err != fs.SkipAll {
+		return nil, w.err
+	}
+	sort.Slice(w.matches, func(i, j int) bool {
+		return w.matches[i].less(w.matches[j], cfg.order)
+	})
+	return w.matches, nil
+}
+
+// walker holds the state of a concurrent walk. Directories waiting to be read
+// are queued, and pending counts the directories queued or being read.
+type walker struct {
+	ctx      context.Context
+	fsys     fs.FS
+	index    func(path string) int
+	under    func(dir string) bool
+	prune    func(dir string) bool
+	dirOnly  bool
+	maxDepth int
+	follow   bool
+	types    EntryType
+	onError  func(path string, err error) error
+	limit    int
+	onMatch  func(path string) error
+
+	ignoreFiles []string
+
+	// metrics receives the counters of the walk, if set when it started.
+	metrics Metrics
+
+	// cbMu serializes the calls to onError and prune.
+	cbMu sync.Mutex
+
+	mu      sync.Mutex
+	cond    sync.Cond
+	queue   []walkDir
+	pending int
+	matches []walkMatch
+	err     error
+}
+
+// walkMatch is a path matching the pattern of the given index.
+type walkMatch struct {
+	path  string
+	dir   bool
+	index int
+}
+
+// walkDir is a directory to read. When following symbolic links, ancestors
+// holds the information of the directory and of all its parents. ignores
+// holds the ignore files applying to the directory, loaded from its
+// parents.
+type walkDir struct {
+	path      string
+	ancestors []fs.FileInfo
+	ignores   *ignoreChain
+}
+
+// work reads queued directories until there are none left, or the walk
+// failed.
+func (w *walker) work() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for {
+		for len(w.queue) == 0 && w.pending > 0 && w.err == nil {
+			w.cond.Wait()
+		}
+		if w.pending == 0 || w.err != nil {
+			return
+		}
+		dir := w.queue[len(w.queue)-1]
+		w.queue = w.queue[:len(w.queue)-1]
+
+		w.mu.Unlock()
+		matches, subdirs, err := w.readDir(dir)
+		w.mu.Lock()
+
+		w.add(matches)
+		w.queue = append(w.queue, subdirs...)
+		w.pending += len(subdirs) - 1
+		if err != nil && w.err == nil {
+			w.err = err
+		}
+		w.cond.Broadcast()
+	}
+}
+
+// readDir returns the entries of dir matching the glob, and its
+// subdirectories.
+func (w *walker) readDir(dir walkDir) (matches []walkMatch, subdirs []walkDir, err error) {
+	if err := w.ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	entries, err := fs.ReadDir(w.fsys, dir.path)
+	if err != nil {
+		return nil, nil, w.handle(dir.path, err)
+	}
+	ignores := dir.ignores
+	if len(w.ignoreFiles) != 0 {
+		if ignores, err = w.loadIgnores(ignores, dir.path, entries); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, entry := range entries {
+		path := entry.Name()
+		if dir.path != "." {
+			path = dir.path + "/" + path
+		}
+		typ := entry.Type()
+		var info fs.FileInfo
+		if w.follow {
+			if typ&fs.ModeSymlink != 0 {
+				if target, err := fs.Stat(w.fsys, path); err == nil {
+					info, typ = target, target.Mode().Type()
+				}
+			} else if typ.IsDir() {
+				if info, err = entry.Info(); err != nil {
+					if err := w.handle(path, err); err != nil {
+						return nil, nil, err
+					}
+					continue
+				}
+			}
+		}
+		isDir := typ.IsDir()
+		if ignores.ignored(path, isDir) {
+			continue
+		}
+		if m, ok := w.match(path, typ); ok {
+			matches = append(matches, m)
+		}
+		if !isDir || w.atMaxDepth(path) || w.pruned(path) {
+			continue
+		}
+		if !w.follow {
+			subdirs = append(subdirs, walkDir{path: path, ignores: ignores})
+		} else if !cyclic(info, dir.ancestors) {
+			ancestors := append(dir.ancestors[:len(dir.ancestors):len(dir.ancestors)], info)
+			subdirs = append(subdirs, walkDir{path: path, ancestors: ancestors, ignores: ignores})
+		}
+	}
+	return matches, subdirs, nil
+}
+
+// cyclic returns whether the directory of info is one of ancestors.
+func cyclic(info fs.FileInfo, ancestors []fs.FileInfo) bool {
+	for _, ancestor := range ancestors {
+		if os.SameFile(info, ancestor) {
+			return true
+		}
+	}
+	return false
+}
+
+// handle passes err, which happened reading path, to the error handler of
+// the walk, if any, and returns the error the walk must stop with, if any.
+func (w *walker) handle(path string, err error) error {
+	if w.onError == nil {
+		return err
+	}
+	w.cbMu.Lock()
+	defer w.cbMu.Unlock()
+	return w.onError(path, err)
+}
+
+// pruned returns whether the contents of the directory at path are not
+// walked, either because they cannot match, or because the prune function
+// of the walk says so.
+func (w *walker) pruned(path string) bool {
+	if !w.under(path) {
+		return true
+	}
+	if w.prune == nil {
+		return false
+	}
+	w.cbMu.Lock()
+	defer w.cbMu.Unlock()
+	return w.prune(path)
+}
+
+// atMaxDepth returns whether the entries of the directory at path are beyond
+// the maximum depth of the walk, if any.
+func (w *walker) atMaxDepth(path string) bool {
+	return w.maxDepth > 0 && pathDepth(path) >= w.maxDepth
+}
+
+// tooDeep returns whether path is beyond the maximum depth of the walk, if
+// any.
+func (w *walker) tooDeep(path string) bool {
+	return w.maxDepth > 0 && pathDepth(path) > w.maxDepth
+}
+
+// pathDepth returns the number of components of the fs.FS path.
+func pathDepth(path string) int {
+	if path == "." {
+		return 0
+	}
+	return strings.Count(path, "/") + 1
+}
+
+// visit records path if it matches the glob.
+func (w *walker) visit(path string, typ fs.FileMode) {
+	if m, ok := w.match(path, typ); ok {
+		w.add([]walkMatch{m})
+	}
+}
+
+// add records matches, unless the walk already stopped, and stops the walk
+// once it reaches its limit or the match callback asks to. Stopping early is
+// recorded as fs.SkipAll. w.mu must be held once walking started.
+func (w *walker) add(matches []walkMatch) {
+	for _, m := range matches {
+		if w.err != nil {
+			return
+		}
+		w.matches = append(w.matches, m)
+		if w.onMatch != nil {
+			w.err = w.onMatch(m.path)
+		}
+		if w.limit > 0 && len(w.matches) >= w.limit && w.err == nil {
+			w.err = fs.SkipAll
+		}
+	}
+}
+
+// match returns the match for path, of type typ, if it is part of the
+// results.
+func (w *walker) match(path string, typ fs.FileMode) (walkMatch, bool) {
+	if w.metrics != nil {
+		w.metrics.Add(MetricFilesVisited, 1)
+	}
+	isDir := typ.IsDir()
+	if w.dirOnly && !isDir || !w.types.includes(typ) {
+		return walkMatch{}, false
+	}
+	i := w.index(path)
+	if i == -1 && isDir {
+		i = w.index(path + "/")
+	}
+	return walkMatch{path: path, dir: isDir, index: i}, i != -1
+}
+
+// less returns whether m comes before o in the specified order.
+func (m walkMatch) less(o walkMatch, order SortOrder) bool {
+	switch order {
+	case SortLexical:
+		return m.path < o.path
+	case SortPatterns:
+		if m.index != o.index {
+			return m.index < o.index
+		}
+	}
+	return walkLess(m.path, m.dir, o.path, o.dir, order == SortDirsFirst)
+}
+
+// walkLess orders paths the way fs.WalkDir visits them: component by
+// component, a directory coming before its contents. If dirsFirst is set,
+// directories come before the other entries of their parent; aDir and bDir
+// tell whether a and b are directories.
+func walkLess(a string, aDir bool, b string, bDir bool, dirsFirst bool) bool {
+	for {
+		ia, ib := strings.IndexByte(a, '/'), strings.IndexByte(b, '/')
+		ca, cb := a, b
+		if ia != -1 {
+			ca = a[:ia]
+		}
+		if ib != -1 {
+			cb = b[:ib]
+		}
+		if ca != cb {
+			if da, db := ia != -1 || aDir, ib != -1 || bDir; dirsFirst && da != db {
+				return da
+			}
+			return ca < cb
+		}
+		if ia == -1 || ib == -1 {
+			return ia == -1 && ib != -1
+		}
+		a, b = a[ia+1:], b[ib+1:]
+	}
+}
+
+// GlobWalk compiles pattern, and then returns Glob.Walk(fsys, opts...).
+func GlobWalk(fsys fs.FS, pattern string, opts ...WalkOption) ([]string, error) {
+	return GlobWalkContext(context.Background(), fsys, pattern, opts...)
+}
+
+// GlobWalkContext compiles pattern, and then returns
+// Glob.WalkContext(ctx, fsys, opts...).
+func GlobWalkContext(ctx context.Context, fsys fs.FS, pattern string, opts ...WalkOption) ([]string, error) {
+	g, err := CompileGlob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return g.WalkContext(ctx, fsys, opts...)
+}
@@ -0,0 +1,510 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+func TestGlobWalk(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.go":               {},
+		"README.md":             {},
+		"cmd/tool/main.go":      {},
+		"cmd/tool/main_test.go": {},
+		"internal/x/x.go":       {},
+		"internal/x/doc.md":     {},
+	}
+
+	tcases := []struct {
+		Pattern  string
+		Expected []string
+	}{
+		{"*.go", []string{"main.go"}},
+		{"**/*.go", []string{"cmd/tool/main.go", "cmd/tool/main_test.go", "internal/x/x.go", "main.go"}},
+		{"**/*_test.go", []string{"cmd/tool/main_test.go"}},
+		{"cmd/**", []string{"cmd", "cmd/tool", "cmd/tool/main.go", "cmd/tool/main_test.go"}},
+		{"*/", []string{"cmd", "internal"}},
+		{"**/x/", []string{"internal/x"}},
+		{"{cmd,internal}/*/*.md", []string{"internal/x/doc.md"}},
+		{"*.c", nil},
+		{"missing/**", nil},
+		{"/abs/**", nil},
+		{"cmd/tool/main.go", []string{"cmd/tool/main.go"}},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			actual, err := GlobWalk(fsys, tc.Pattern)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+		})
+	}
+
+	if _, err := GlobWalk(fsys, "["); err == nil {
+		t.Fatalf("expected error for invalid pattern")
+	}
+}
+
+func TestGlobWalkMaxDepth(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.go":         {},
+		"x/b.go":       {},
+		"x/y/c.go":     {},
+		"x/y/z/d.go":   {},
+		"x/y/z/w/e.go": {},
+	}
+
+	tcases := []struct {
+		Pattern  string
+		MaxDepth int
+		Expected []string
+	}{
+		{"**/*.go", 0, []string{"a.go", "x/b.go", "x/y/c.go", "x/y/z/d.go", "x/y/z/w/e.go"}},
+		{"**/*.go", 1, []string{"a.go"}},
+		{"**/*.go", 3, []string{"a.go", "x/b.go", "x/y/c.go"}},
+		{"**/", 2, []string{"x", "x/y"}},
+		{"x/y/**", 3, []string{"x/y", "x/y/c.go", "x/y/z"}},
+		{"x/y/z/**", 2, nil},
+		{"x/y/z/**", 3, []string{"x/y/z"}},
+	}
+
+	for _, tc := range tcases {
+		t.Run(fmt.Sprint(tc.Pattern, " ", tc.MaxDepth), func(t *testing.T) {
+			actual, err := GlobWalk(fsys, tc.Pattern, WalkMaxDepth(tc.MaxDepth))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestGlobWalkSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"src/a.go", "lib/b.go"} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	links := map[string]string{
+		"src/lib":    "../lib",
+		"src/loop":   "..",
+		"src/c.go":   "a.go",
+		"src/broken": "missing",
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			t.Skipf("cannot create symbolic links: %v", err)
+		}
+	}
+	fsys := os.DirFS(dir)
+
+	tcases := []struct {
+		Pattern  string
+		Mode     SymlinkMode
+		Expected []string
+	}{
+		{"src/**/*.go", SymlinksAsEntries, []string{"src/a.go", "src/c.go"}},
+		{"src/*/", SymlinksAsEntries, []string{"src"}},
+		{"src/?*", SymlinksAsEntries, []string{"src/a.go", "src/broken", "src/c.go", "src/lib", "src/loop"}},
+		{"src/**/*.go", SymlinksFollow, []string{"src/a.go", "src/c.go", "src/lib/b.go"}},
+		{"src/*/", SymlinksFollow, []string{"src", "src/lib", "src/loop"}},
+		{"**/broken", SymlinksFollow, []string{"src/broken"}},
+		{"**/b.go", SymlinksFollow, []string{"lib/b.go", "src/lib/b.go"}},
+	}
+
+	for _, tc := range tcases {
+		t.Run(fmt.Sprint(tc.Pattern, " ", tc.Mode), func(t *testing.T) {
+			actual, err := GlobWalk(fsys, tc.Pattern, WalkSymlinks(tc.Mode))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestGlobWalkTypes(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.go":       {},
+		"b.go":       {Data: []byte("a.go"), Mode: fs.ModeSymlink},
+		"c.go":       {Mode: fs.ModeNamedPipe},
+		"d.go/x.go":  {},
+		"empty.go":   {Mode: fs.ModeDir},
+		"sub/e.go":   {},
+		"sub/f.go/y": {},
+	}
+
+	tcases := []struct {
+		Types    EntryType
+		Expected []string
+	}{
+		{0, []string{"a.go", "b.go", "c.go", "d.go", "d.go/x.go", "empty.go", "sub/e.go", "sub/f.go"}},
+		{TypeFile, []string{"a.go", "d.go/x.go", "sub/e.go"}},
+		{TypeDir, []string{"d.go", "empty.go", "sub/f.go"}},
+		{TypeSymlink, []string{"b.go"}},
+		{TypeOther, []string{"c.go"}},
+		{TypeFile | TypeSymlink, []string{"a.go", "b.go", "d.go/x.go", "sub/e.go"}},
+	}
+
+	for _, tc := range tcases {
+		t.Run(fmt.Sprint(tc.Types), func(t *testing.T) {
+			actual, err := GlobWalk(fsys, "**/*.go", WalkTypes(tc.Types))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+// denyFS fails reading the directories in deny.
+type denyFS struct {
+	fstest.MapFS
+	deny map[string]bool
+}
+
+func (fsys denyFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if fsys.deny[name] {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrPermission}
+	}
+	return fsys.MapFS.ReadDir(name)
+}
+
+func TestGlobWalkOnError(t *testing.T) {
+	fsys := denyFS{
+		MapFS: fstest.MapFS{
+			"a.go":         {},
+			"priv/b.go":    {},
+			"pub/c.go":     {},
+			"pub/sec/d.go": {},
+		},
+		deny: map[string]bool{"priv": true, "pub/sec": true},
+	}
+
+	if _, err := GlobWalk(fsys, "**/*.go"); !errors.Is(err, fs.ErrPermission) {
+		t.Fatalf("expected %v, got %v", fs.ErrPermission, err)
+	}
+
+	var skipped []string
+	skip := func(path string, err error) error {
+		if !errors.Is(err, fs.ErrPermission) {
+			return err
+		}
+		skipped = append(skipped, path)
+		return nil
+	}
+	actual, err := GlobWalk(fsys, "**/*.go", WalkOnError(skip))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"a.go", "pub/c.go"}; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %q, got %q", expected, actual)
+	}
+	sort.Strings(skipped)
+	if expected := []string{"priv", "pub/sec"}; !reflect.DeepEqual(skipped, expected) {
+		t.Fatalf("expected %q to be skipped, got %q", expected, skipped)
+	}
+
+	errStop := errors.New("stop")
+	stop := func(path string, err error) error {
+		return errStop
+	}
+	if _, err := GlobWalk(fsys, "**/*.go", WalkOnError(stop)); err != errStop {
+		t.Fatalf("expected %v, got %v", errStop, err)
+	}
+}
+
+func TestGlobWalkSort(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a-b":     {},
+		"a/c":     {},
+		"b/d/e":   {},
+		"b/f":     {},
+		"c":       {},
+		"d/g.txt": {},
+	}
+
+	tcases := []struct {
+		Order    SortOrder
+		Expected []string
+	}{
+		{SortTree, []string{"a", "a/c", "a-b", "b", "b/d", "b/d/e", "b/f", "c", "d", "d/g.txt"}},
+		{SortLexical, []string{"a", "a-b", "a/c", "b", "b/d", "b/d/e", "b/f", "c", "d", "d/g.txt"}},
+		{SortDirsFirst, []string{"a", "a/c", "b", "b/d", "b/d/e", "b/f", "d", "d/g.txt", "a-b", "c"}},
+		{SortPatterns, []string{"a", "a/c", "a-b", "b", "b/d", "b/d/e", "b/f", "c", "d", "d/g.txt"}},
+	}
+
+	for _, tc := range tcases {
+		t.Run(fmt.Sprint(tc.Order), func(t *testing.T) {
+			actual, err := GlobWalk(fsys, "**", WalkSort(tc.Order))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestGlobSetWalk(t *testing.T) {
+	fsys := fstest.MapFS{
+		"README.md":          {},
+		"src/main.go":        {},
+		"src/doc.md":         {},
+		"src/lib/lib.go":     {},
+		"src/lib/lib.md":     {},
+		"testdata/x/main.go": {},
+	}
+
+	tcases := []struct {
+		Patterns []string
+		Order    SortOrder
+		Expected []string
+	}{
+		{[]string{"**/*.md", "src/**/*.go"}, SortTree, []string{"README.md", "src/doc.md", "src/lib/lib.go", "src/lib/lib.md", "src/main.go"}},
+		{[]string{"**/*.md", "src/**/*.go"}, SortPatterns, []string{"README.md", "src/doc.md", "src/lib/lib.md", "src/lib/lib.go", "src/main.go"}},
+		{[]string{"src/**/*.go", "**/*.md"}, SortPatterns, []string{"src/lib/lib.go", "src/main.go", "README.md", "src/doc.md", "src/lib/lib.md"}},
+		{[]string{"src/*.go", "src/lib/*.md"}, SortTree, []string{"src/lib/lib.md", "src/main.go"}},
+		{[]string{"src/lib/", "testdata/x/"}, SortTree, []string{"src/lib", "testdata/x"}},
+		{nil, SortTree, nil},
+	}
+
+	for _, tc := range tcases {
+		t.Run(fmt.Sprint(tc.Patterns, tc.Order), func(t *testing.T) {
+			actual, err := MustCompileGlobSet(tc.Patterns).Walk(fsys, WalkSort(tc.Order))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestGlobSetWalkIndices(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.go":          {},
+		"main_test.go":     {},
+		"src/lib.go":       {},
+		"src/doc.md":       {},
+		"testdata/x/a.txt": {},
+	}
+	set := MustCompileGlobSet([]string{"**/*.go", "*_test.go", "src/**", "**/", "**/*.go"})
+
+	actual, err := set.WalkIndices(fsys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []SetMatch{
+		{"main.go", []int{0, 4}},
+		{"main_test.go", []int{0, 1, 4}},
+		{"src", []int{2, 3}},
+		{"src/doc.md", []int{2}},
+		{"src/lib.go", []int{0, 2, 4}},
+		{"testdata", []int{3}},
+		{"testdata/x", []int{3}},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestCommonDir(t *testing.T) {
+	tcases := []struct {
+		A, B, Expected string
+	}{
+		{"a/b", "a/b", "a/b"},
+		{"a/b", "a/c", "a"},
+		{"a", "a/b/c", "a"},
+		{"ab/c", "a/c", "."},
+		{".", "a", "."},
+	}
+
+	for _, tc := range tcases {
+		if actual := commonDir(tc.A, tc.B); actual != tc.Expected {
+			t.Errorf("commonDir(%q, %q): expected %q, got %q", tc.A, tc.B, tc.Expected, actual)
+		}
+	}
+}
+
+func TestGlobWalkLimit(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			fsys[fmt.Sprintf("d%d/e%d/f.go", i, j)] = &fstest.MapFile{}
+		}
+	}
+
+	for _, limit := range []int{1, 5, 100, 1000} {
+		t.Run(fmt.Sprint(limit), func(t *testing.T) {
+			actual, err := GlobWalk(fsys, "**/*.go", WalkLimit(limit))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if expected := min(limit, 100); len(actual) != expected {
+				t.Fatalf("expected %d paths, got %q", expected, actual)
+			}
+			for _, path := range actual {
+				if _, ok := fsys[path]; !ok {
+					t.Fatalf("unexpected path %q", path)
+				}
+			}
+		})
+	}
+
+	t.Run("OnMatch", func(t *testing.T) {
+		var found []string
+		actual, err := GlobWalk(fsys, "d3/**/*.go", WalkOnMatch(func(path string) error {
+			found = append(found, path)
+			if len(found) == 3 {
+				return fs.SkipAll
+			}
+			return nil
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		sort.Strings(found)
+		if !reflect.DeepEqual(actual, found) || len(actual) != 3 {
+			t.Fatalf("expected %q, got %q", found, actual)
+		}
+	})
+
+	t.Run("OnMatchError", func(t *testing.T) {
+		errStop := errors.New("stop")
+		_, err := GlobWalk(fsys, "**/*.go", WalkOnMatch(func(path string) error {
+			return errStop
+		}))
+		if err != errStop {
+			t.Fatalf("expected %v, got %v", errStop, err)
+		}
+	})
+}
+
+// readDirFS records the directories read.
+type readDirFS struct {
+	fstest.MapFS
+	mu   sync.Mutex
+	read []string
+}
+
+func (fsys *readDirFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	fsys.mu.Lock()
+	fsys.read = append(fsys.read, name)
+	fsys.mu.Unlock()
+	return fsys.MapFS.ReadDir(name)
+}
+
+func TestGlobWalkPrune(t *testing.T) {
+	mapFS := fstest.MapFS{
+		"a.go":                     {},
+		"node_modules/x/x.go":      {},
+		"src/b.go":                 {},
+		"src/node_modules/y/y.go":  {},
+		"src/vendor/c.go":          {},
+		"docs/d.md":                {},
+		"docs/node_modules/z/z.go": {},
+	}
+	excluded := MustCompileGlob("**/node_modules/**")
+
+	tcases := []struct {
+		Pattern  string
+		Prune    func(string) bool
+		Expected []string
+		Read     []string
+	}{
+		{"src/*.go", nil, []string{"src/b.go"}, []string{"src"}},
+		{"*/vendor/*.go", nil, []string{"src/vendor/c.go"}, []string{".", "docs", "node_modules", "src", "src/vendor"}},
+		{"**/*.go", func(dir string) bool { return excluded.Match(dir + "/") }, []string{"a.go", "src/b.go", "src/vendor/c.go"}, []string{".", "docs", "src", "src/vendor"}},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			fsys := &readDirFS{MapFS: mapFS}
+			actual, err := GlobWalk(fsys, tc.Pattern, WalkPrune(tc.Prune))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %q, got %q", tc.Expected, actual)
+			}
+			sort.Strings(fsys.read)
+			if !reflect.DeepEqual(fsys.read, tc.Read) {
+				t.Fatalf("expected to read %q, read %q", tc.Read, fsys.read)
+			}
+		})
+	}
+}
+
+func TestGlobWalkContext(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for i := 0; i < 20; i++ {
+		for j := 0; j < 10; j++ {
+			fsys[fmt.Sprintf("d%d/e%d/f.go", i, j)] = &fstest.MapFile{}
+			fsys[fmt.Sprintf("d%d/e%d-x.go", i, j)] = &fstest.MapFile{}
+		}
+	}
+
+	g := MustCompileGlob("**/*.go")
+	var expected []string
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if g.Match(path) {
+			expected = append(expected, path)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, workers := range []int{1, 2, 8, 0} {
+		t.Run(fmt.Sprint(workers), func(t *testing.T) {
+			actual, err := g.WalkContext(context.Background(), fsys, WalkWorkers(workers))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, expected) {
+				t.Fatalf("expected %q, got %q", expected, actual)
+			}
+		})
+	}
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := GlobWalkContext(ctx, fsys, "**"); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected %v, got %v", context.Canceled, err)
+		}
+	})
+}
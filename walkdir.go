@@ -0,0 +1,103 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"io/fs"
+)
+
+// WalkDirFunc returns an fs.WalkDirFunc calling fn with the paths matching
+// the glob pattern, and returning fs.SkipDir for the directories no path
+// under which can match, as in:
+//
+//	fs.WalkDir(fsys, ".", g.WalkDirFunc(fn))
+//
+// Directories are matched like with MatchEntry: a directory also matches if
+// its path followed by "/" matches. Errors reading a directory are always
+// passed to fn, which decides how to handle them. As with Walk, the root "."
+// is neither matched nor skipped.
+func (g *Glob) WalkDirFunc(fn fs.WalkDirFunc) fs.WalkDirFunc {
+	return walkDirFunc(fn, g.Match, g.canMatchUnder)
+}
+
+// WalkDirFunc returns an fs.WalkDirFunc calling fn with the paths matching at
+// least one pattern of the set. See Glob.WalkDirFunc for details.
+func (s *GlobSet) WalkDirFunc(fn fs.WalkDirFunc) fs.WalkDirFunc {
+	return walkDirFunc(fn, s.Match, s.canMatchUnder)
+}
+
+// WalkDirFunc returns an fs.WalkDirFunc calling fn with the paths that are
+// not ignored by the set, and returning fs.SkipDir for ignored directories,
+// so that their contents are not walked either. As with Match, a directory
+// is also ignored if its path followed by "/" is. Errors reading a directory
+// are always passed to fn, and the root of the walk is never skipped.
+func (s *IgnoreSet) WalkDirFunc(fn fs.WalkDirFunc) fs.WalkDirFunc {
+	return func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == "." {
+			return fn(path, d, err)
+		}
+		if d.IsDir() {
+			if s.Match(path + "/") {
+				return fs.SkipDir
+			}
+		} else if s.Match(path) {
+			return nil
+		}
+		return fn(path, d, err)
+	}
+}
+
+// walkDirFunc returns an fs.WalkDirFunc calling fn with the paths other than
+// "." for which match returns true, and skipping the directories under which no path can
+// match, as reported by under.
+func walkDirFunc(fn fs.WalkDirFunc, match func(string) bool, under func(string) bool) fs.WalkDirFunc {
+	return func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return fn(path, d, err)
+		}
+		if path == "." {
+			return nil
+		}
+		isDir := d.IsDir()
+		if match(path) || isDir && match(path+"/") {
+			if err := fn(path, d, nil); err != nil {
+				return err
+			}
+		}
+		if isDir && !under(path) {
+			return fs.SkipDir
+		}
+		return nil
+	}
+}
+
+// canMatchUnder returns whether at least one glob of the set may match paths
+// under the directory dir. Negated globs only exclude paths, and are skipped.
+func (s *GlobSet) canMatchUnder(dir string) bool {
+	for _, g := range s.globs {
+		if !g.negated && g.canMatchUnder(dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// canMatchUnder returns whether the glob may match paths under the directory
+// dir of a walk, whose paths are separated by "/" whatever the options of
+// the glob.
+func (g *Glob) canMatchUnder(dir string) bool {
+	return g.matchPrefix(dir + "/")
+}
+
+// matchPrefix returns whether a string starting with prefix may match the
+// glob.
+func (g *Glob) matchPrefix(prefix string) bool {
+	if g.negated || g.opts.MatchBase {
+		return true
+	}
+	in := g.opts.input()
+	return g.prog.viable(in.prepare(prefix, '/'))
+}
@@ -0,0 +1,112 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"io/fs"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+var walkDirFS = fstest.MapFS{
+	"main.go":            {},
+	"README.md":          {},
+	"src/a.go":           {},
+	"src/lib/b.go":       {},
+	"src/lib/b.md":       {},
+	"testdata/c.go":      {},
+	"testdata/deep/d.go": {},
+}
+
+// walkDirPaths walks walkDirFS with the fs.WalkDirFunc returned by adapt,
+// and returns the paths passed to the adapted function and the directories
+// read.
+func walkDirPaths(t *testing.T, adapt func(fs.WalkDirFunc) fs.WalkDirFunc) (paths, read []string) {
+	t.Helper()
+	fn := adapt(func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		paths = append(paths, path)
+		return nil
+	})
+	err := fs.WalkDir(walkDirFS, ".", func(path string, d fs.DirEntry, err error) error {
+		err = fn(path, d, err)
+		if err == nil && d.IsDir() {
+			read = append(read, path)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return paths, read
+}
+
+func TestGlobWalkDirFunc(t *testing.T) {
+	tcases := []struct {
+		Pattern  string
+		Expected []string
+		Read     []string
+	}{
+		{"src/**/*.go", []string{"src/a.go", "src/lib/b.go"}, []string{".", "src", "src/lib"}},
+		{"*/", []string{"src", "testdata"}, []string{".", "src", "testdata"}},
+		{"src/lib", []string{"src/lib"}, []string{".", "src"}},
+		{"**/*.md", []string{"README.md", "src/lib/b.md"}, []string{".", "src", "src/lib", "testdata", "testdata/deep"}},
+		{"{src,testdata}/*.go", []string{"src/a.go", "testdata/c.go"}, []string{".", "src", "testdata"}},
+		{"!src/**", []string{"src", "src/a.go", "src/lib", "src/lib/b.go", "src/lib/b.md"}, []string{".", "src", "src/lib", "testdata", "testdata/deep"}},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			g := MustCompileGlob(tc.Pattern)
+			paths, read := walkDirPaths(t, g.WalkDirFunc)
+			if !reflect.DeepEqual(paths, tc.Expected) {
+				t.Fatalf("expected %q, got %q", tc.Expected, paths)
+			}
+			if !reflect.DeepEqual(read, tc.Read) {
+				t.Fatalf("expected to read %q, read %q", tc.Read, read)
+			}
+		})
+	}
+}
+
+func TestGlobSetWalkDirFunc(t *testing.T) {
+	s := MustCompileGlobSet([]string{"src/lib/*.md", "testdata/*.go"})
+	paths, read := walkDirPaths(t, s.WalkDirFunc)
+	if expected := []string{"src/lib/b.md", "testdata/c.go"}; !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("expected %q, got %q", expected, paths)
+	}
+	if expected := []string{".", "src", "src/lib", "testdata"}; !reflect.DeepEqual(read, expected) {
+		t.Fatalf("expected to read %q, read %q", expected, read)
+	}
+}
+
+func TestIgnoreSetWalkDirFunc(t *testing.T) {
+	s := MustCompileIgnoreSet([]string{"testdata", "**/*.md", "!README.md"})
+	paths, read := walkDirPaths(t, s.WalkDirFunc)
+	expected := []string{".", "README.md", "main.go", "src", "src/a.go", "src/lib", "src/lib/b.go"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("expected %q, got %q", expected, paths)
+	}
+	if expected := []string{".", "src", "src/lib"}; !reflect.DeepEqual(read, expected) {
+		t.Fatalf("expected to read %q, read %q", expected, read)
+	}
+}
+
+func TestWalkDirFuncError(t *testing.T) {
+	errWalk := errors.New("walk error")
+	var got error
+	fn := MustCompileGlob("*.go").WalkDirFunc(func(path string, d fs.DirEntry, err error) error {
+		got = err
+		return nil
+	})
+	if err := fn("x", nil, errWalk); err != nil || got != errWalk {
+		t.Fatalf("expected %v to be passed, got %v, %v", errWalk, got, err)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// WalkIgnoreFiles makes walks load the ignore files with the given names,
+// like ".gitignore", from each directory they read, and skip the paths the
+// files ignore, as git and ripgrep do. The files are parsed with
+// ParseIgnoreFile, and their rules apply to the paths under their
+// directory, relative to it. The rules of deeper files take precedence over
+// the ones of their parents, and in a directory, the rules of the files
+// named later take precedence. Ignored directories are not walked, so that
+// their contents cannot be re-included, as with git.
+//
+// The ignore files of the directories above the root of the walk also
+// apply. Errors reading ignore files are handled like the errors reading
+// directories, see WalkOnError.
+func WalkIgnoreFiles(names ...string) WalkOption {
+	return func(cfg *walkConfig) {
+		cfg.ignoreFiles = names
+	}
+}
+
+// ignoreChain is a list of the ignore files applying to a directory, the
+// deepest one first.
+type ignoreChain struct {
+	dir    string
+	set    *IgnoreSet
+	parent *ignoreChain
+}
+
+// ignored returns whether the path, a directory if isDir is set, is ignored
+// by the deepest file of c that has a rule matching it.
+func (c *ignoreChain) ignored(p string, isDir bool) bool {
+	for ; c != nil; c = c.parent {
+		rel := p
+		if c.dir != "." {
+			rel = strings.TrimPrefix(p, c.dir+"/")
+		}
+		if isDir {
+			rel += "/"
+		}
+		if ignored, decided := c.set.decide(rel); decided {
+			return ignored
+		}
+	}
+	return false
+}
+
+// loadIgnores returns c extended with the ignore files of the directory
+// dir, whose entries are given. If entries is nil, the files are looked up
+// in dir instead.
+func (w *walker) loadIgnores(c *ignoreChain, dir string, entries []fs.DirEntry) (*ignoreChain, error) {
+	for _, name := range w.ignoreFiles {
+		if entries != nil && !hasFileEntry(entries, name) {
+			continue
+		}
+		p := path.Join(dir, name)
+		set, err := readIgnoreFile(w.fsys, p)
+		if entries == nil && errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			if err := w.handle(p, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		c = &ignoreChain{dir: dir, set: set, parent: c}
+	}
+	return c, nil
+}
+
+// rootIgnores returns the ignore files applying to the root of the walk,
+// loaded from the directories above it, and whether one of these
+// directories is ignored.
+func (w *walker) rootIgnores(root string) (*ignoreChain, bool, error) {
+	if root == "." {
+		return nil, false, nil
+	}
+	var c *ignoreChain
+	dirs := append([]string{"."}, strings.Split(root, "/")...)
+	dir := ""
+	for _, name := range dirs[:len(dirs)-1] {
+		dir = path.Join(dir, name)
+		if dir != "." && c.ignored(dir, true) {
+			return nil, true, nil
+		}
+		var err error
+		if c, err = w.loadIgnores(c, dir, nil); err != nil {
+			return nil, false, err
+		}
+	}
+	return c, false, nil
+}
+
+// hasFileEntry returns whether entries contain a file named name.
+func hasFileEntry(entries []fs.DirEntry, name string) bool {
+	for _, entry := range entries {
+		if entry.Name() == name {
+			return !entry.IsDir()
+		}
+	}
+	return false
+}
+
+// readIgnoreFile parses the ignore file at the path name of fsys.
+func readIgnoreFile(fsys fs.FS, name string) (*IgnoreSet, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseIgnoreFile(f)
+}
@@ -0,0 +1,85 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestGlobWalkIgnoreFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		".gitignore":           {Data: []byte("*.log\nbuild/\n/top.txt\n")},
+		"top.txt":              {},
+		"a.txt":                {},
+		"a.log":                {},
+		"build/x.txt":          {},
+		"src/.gitignore":       {Data: []byte("!keep.log\ngen/\n*.txt\n!/b.txt\n")},
+		"src/keep.log":         {},
+		"src/other.log":        {},
+		"src/b.txt":            {},
+		"src/c.txt":            {},
+		"src/top.txt":          {},
+		"src/gen/x.go":         {},
+		"src/lib/.ignore":      {Data: []byte("*.go\n")},
+		"src/lib/.gitignore":   {Data: []byte("!*.go\n")},
+		"src/lib/y.go":         {},
+		"src/lib/sub/keep.log": {},
+	}
+
+	tcases := []struct {
+		Pattern  string
+		Names    []string
+		Expected []string
+	}{
+		{"**/*.txt", []string{".gitignore"}, []string{"a.txt", "src/b.txt"}},
+		{"**/*.log", []string{".gitignore"}, []string{"src/keep.log", "src/lib/sub/keep.log"}},
+		{"src/**/*.go", []string{".gitignore"}, []string{"src/lib/y.go"}},
+		{"src/**/*.go", []string{".gitignore", ".ignore"}, nil},
+		{"src/**/*.go", []string{".ignore", ".gitignore"}, []string{"src/lib/y.go"}},
+		{"src/gen/x.go", []string{".gitignore"}, nil},
+		{"build/**", []string{".gitignore"}, nil},
+		{"build/**", nil, []string{"build", "build/x.txt"}},
+		{"*", []string{".gitignore"}, []string{".gitignore", "a.txt", "src"}},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.Pattern, func(t *testing.T) {
+			g := MustCompileGlob(tc.Pattern)
+			matches, err := g.Walk(fsys, WalkIgnoreFiles(tc.Names...))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(matches, tc.Expected) {
+				t.Fatalf("expected %q, got %q", tc.Expected, matches)
+			}
+		})
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			".gitignore": {Data: []byte("[a\n")},
+			"a.txt":      {},
+		}
+		g := MustCompileGlob("*.txt")
+		if _, err := g.Walk(fsys, WalkIgnoreFiles(".gitignore")); err == nil {
+			t.Fatal("expected error for invalid ignore file")
+		}
+
+		var failed []string
+		matches, err := g.Walk(fsys, WalkIgnoreFiles(".gitignore"), WalkOnError(func(path string, err error) error {
+			failed = append(failed, path)
+			return nil
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(matches, []string{"a.txt"}) || !reflect.DeepEqual(failed, []string{".gitignore"}) {
+			t.Fatalf("expected the ignore file to be skipped, got %q and errors for %q", matches, failed)
+		}
+	})
+}
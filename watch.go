@@ -0,0 +1,180 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// WatchOp is the kind of change a WatchEvent reports.
+type WatchOp int
+
+const (
+	// WatchCreate reports a path that did not exist or did not match
+	// before.
+	WatchCreate WatchOp = iota + 1
+
+	// WatchModify reports a path whose size or modification time changed.
+	WatchModify
+)
+
+func (op WatchOp) String() string {
+	switch op {
+	case WatchCreate:
+		return "create"
+	case WatchModify:
+		return "modify"
+	}
+	return "unknown"
+}
+
+// A WatchEvent reports a change to a path matching the watched set. Path is
+// relative to the watched root, as returned by GlobSet.Walk.
+type WatchEvent struct {
+	Path string
+	Op   WatchOp
+}
+
+type watchConfig struct {
+	interval time.Duration
+	walkOpts []WalkOption
+}
+
+// A WatchOption alters the behaviour of WatchGlob.
+type WatchOption func(*watchConfig)
+
+// WatchInterval sets the time between two scans of the watched directory.
+// It defaults to one second, which a zero or negative d also means.
+func WatchInterval(d time.Duration) WatchOption {
+	return func(cfg *watchConfig) {
+		cfg.interval = d
+	}
+}
+
+// WatchWalkOptions sets the options of the walks scanning the watched
+// directory, as in WatchWalkOptions(WalkTypes(TypeFile)).
+func WatchWalkOptions(opts ...WalkOption) WatchOption {
+	return func(cfg *watchConfig) {
+		cfg.walkOpts = opts
+	}
+}
+
+// WatchGlob watches the directory root, and returns a channel of events
+// reporting the paths matching set that are created or modified. The
+// channel is closed once ctx is done.
+//
+// The directory is scanned periodically, as set by WatchInterval, which
+// works on all platforms and filesystems, but does not report changes
+// immediately, nor changes reverted between two scans. Each scan walks only
+// the directories the patterns of the set can match paths in, as
+// GlobSet.Walk does. An error is returned if the initial scan fails; later
+// failed scans are ignored, and retried at the next interval.
+//
+// Events are sent in the order of the paths. Scanning stops while the
+// receiver is not ready, so a slow receiver does not miss events, but
+// receives them late.
+func WatchGlob(ctx context.Context, root string, set *GlobSet, opts ...WatchOption) (<-chan WatchEvent, error) {
+	cfg := watchConfig{interval: time.Second}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.interval <= 0 {
+		cfg.interval = time.Second
+	}
+
+	w := watcher{
+		ctx:  ctx,
+		fsys: os.DirFS(root),
+		set:  set,
+		opts: cfg.walkOpts,
+	}
+	scanned, err := w.scan()
+	if err != nil {
+		return nil, err
+	}
+	files := indexWatched(scanned)
+
+	events := make(chan WatchEvent)
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(cfg.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			scanned, err := w.scan()
+			if err != nil {
+				continue
+			}
+			for _, f := range scanned {
+				var ev WatchEvent
+				switch prev, ok := files[f.path]; {
+				case !ok:
+					ev = WatchEvent{Path: f.path, Op: WatchCreate}
+				case f.size != prev.size || !f.modTime.Equal(prev.modTime):
+					ev = WatchEvent{Path: f.path, Op: WatchModify}
+				default:
+					continue
+				}
+				select {
+				case events <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+			files = indexWatched(scanned)
+		}
+	}()
+	return events, nil
+}
+
+// watcher scans the paths of fsys matching set.
+type watcher struct {
+	ctx  context.Context
+	fsys fs.FS
+	set  *GlobSet
+	opts []WalkOption
+}
+
+// watchedFile is the state of a matching path at the time of a scan.
+type watchedFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// scan returns the state of the matching paths, in the order of the paths.
+// Paths removed while scanning are left out.
+func (w *watcher) scan() ([]watchedFile, error) {
+	paths, err := w.set.WalkContext(w.ctx, w.fsys, w.opts...)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]watchedFile, 0, len(paths))
+	for _, path := range paths {
+		info, err := fs.Stat(w.fsys, path)
+		if err != nil {
+			continue
+		}
+		files = append(files, watchedFile{path: path, size: info.Size(), modTime: info.ModTime()})
+	}
+	return files, nil
+}
+
+// indexWatched returns files indexed by path.
+func indexWatched(files []watchedFile) map[string]watchedFile {
+	index := make(map[string]watchedFile, len(files))
+	for _, f := range files {
+		index[f.path] = f
+	}
+	return index
+}
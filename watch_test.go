@@ -0,0 +1,87 @@
+// Copyright © 2026 Arista Networks, Inc. All rights reserved.
+//
+// Use of this source code is governed by the MIT license that can be found
+// in the LICENSE file.
+
+package shutil
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWatchGlob(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a.go", "a")
+	write("b.md", "b")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	set := MustCompileGlobSet([]string{"**/*.go"})
+	events, err := WatchGlob(ctx, dir, set, WatchInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	write("a.go", "modified")
+	write("c.md", "c")
+	write("sub/d.go", "d")
+
+	expected := map[string]WatchOp{"a.go": WatchModify, "sub/d.go": WatchCreate}
+	actual := make(map[string]WatchOp)
+	timeout := time.After(10 * time.Second)
+	for len(actual) < len(expected) {
+		select {
+		case ev := <-events:
+			actual[ev.Path] = ev.Op
+		case <-timeout:
+			t.Fatalf("timed out, got %v", actual)
+		}
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatchGlobError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	set := MustCompileGlobSet([]string{"**"})
+	if _, err := WatchGlob(ctx, t.TempDir(), set); err != context.Canceled {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestWatchGlobInterval(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	set := MustCompileGlobSet([]string{"**"})
+	for _, d := range []time.Duration{0, -time.Second} {
+		events, err := WatchGlob(ctx, t.TempDir(), set, WatchInterval(d))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer func() {
+			for range events {
+			}
+		}()
+	}
+	cancel()
+}